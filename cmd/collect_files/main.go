@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	fileRepository "github.com/JamesHsu333/go-twitter/internal/file/repository"
+	fileUseCase "github.com/JamesHsu333/go-twitter/internal/file/usecase"
+	"github.com/JamesHsu333/go-twitter/pkg/database/postgres"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/metric"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Report stored files no record references, and remove them past the grace period in config:
+//
+//	config=docker go run ./cmd/collect_files -dry-run=false
+func main() {
+	dryRun := flag.Bool("dry-run", true, "report orphaned files without removing them")
+	flag.Parse()
+
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+
+	cfgFile, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	fileRepo, err := fileRepository.NewFileRepository(cfg)
+	if err != nil {
+		appLogger.Fatalf("Storage init: %s", err)
+	}
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		appLogger.Fatalf("Postgresql init: %s", err)
+	}
+	defer psqlDB.Close()
+
+	orphanMetrics, err := metric.CreateOrphanMetrics(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateOrphanMetrics: %s", err)
+	}
+
+	collector := fileUseCase.NewCollector(cfg, fileRepository.NewRecordRepository(psqlDB), fileRepo, orphanMetrics, appLogger)
+	result, err := collector.Run(context.Background(), *dryRun)
+
+	// Nothing scrapes a one-shot run, its counters are pushed when a Pushgateway is configured
+	if cfg.FileGC.PushgatewayURL != "" {
+		if pushErr := orphanMetrics.Push(cfg.FileGC.PushgatewayURL, cfg.Metrics.ServiceName+"_collect_files"); pushErr != nil {
+			appLogger.Errorf("Push metrics: %s", pushErr)
+		}
+	}
+
+	if err != nil {
+		appLogger.Fatalf("Collect files: %s", err)
+	}
+
+	appLogger.Infof("Scanned %d files in %s storage, %d orphans of %d bytes, %d removed, %d failed, %d unattached media removed, %d uploads released",
+		result.Scanned, cfg.File.Driver, result.Orphans, result.OrphanBytes, result.Removed, result.Failed, result.Unattached, result.Released)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	fileRepository "github.com/JamesHsu333/go-twitter/internal/file/repository"
+	fileUseCase "github.com/JamesHsu333/go-twitter/internal/file/usecase"
+	"github.com/JamesHsu333/go-twitter/pkg/database/postgres"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Move stored files to the storage driver in config, e.g. after switching from local to s3:
+//
+//	config=docker go run ./cmd/migrate_files -from local
+func main() {
+	from := flag.String("from", fileRepository.LocalDriver, "storage driver files are moved from")
+	fromPublicURL := flag.String("from-public-url", "", "public url of the source storage, if one was configured")
+	deleteSource := flag.Bool("delete-source", false, "remove files from the source storage once moved")
+	flag.Parse()
+
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+
+	cfgFile, err := config.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("LoadConfig: %v", err)
+	}
+
+	cfg, err := config.ParseConfig(cfgFile)
+	if err != nil {
+		log.Fatalf("ParseConfig: %v", err)
+	}
+
+	appLogger := logger.NewApiLogger(cfg)
+	appLogger.InitLogger()
+
+	srcCfg := *cfg
+	srcCfg.File.Driver = *from
+	srcCfg.File.PublicURL = *fromPublicURL
+	if srcCfg.File.Driver == cfg.File.Driver && srcCfg.File.PublicURL == cfg.File.PublicURL {
+		appLogger.Fatalf("Source and destination storage are the same: %s", cfg.File.Driver)
+	}
+
+	src, err := fileRepository.NewFileRepository(&srcCfg)
+	if err != nil {
+		appLogger.Fatalf("Source storage init: %s", err)
+	}
+	dst, err := fileRepository.NewFileRepository(cfg)
+	if err != nil {
+		appLogger.Fatalf("Destination storage init: %s", err)
+	}
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		appLogger.Fatalf("Postgresql init: %s", err)
+	}
+	defer psqlDB.Close()
+
+	migrator := fileUseCase.NewMigrator(fileRepository.NewRecordRepository(psqlDB), src, dst, appLogger)
+	moved, failed, err := migrator.Run(context.Background(), *deleteSource)
+	if err != nil {
+		appLogger.Fatalf("Migrate files: %s", err)
+	}
+
+	appLogger.Infof("Moved %d files from %s to %s, %d failed", moved, srcCfg.File.Driver, cfg.File.Driver, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
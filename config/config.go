@@ -20,6 +20,16 @@ type Config struct {
 	Logger   Logger
 	File     File
 	Jaeger   Jaeger
+	Timeline Timeline
+	Trend    Trend
+	Stream   Stream
+	Message  Message
+	Tweet    Tweet
+	Draft    Draft
+	Upload   Upload
+	FileGC   FileGC
+	Quota    Quota
+	Auth     Auth
 }
 
 // Server config struct
@@ -107,7 +117,107 @@ type Store struct {
 
 // File config
 type File struct {
+	// Storage driver, local or s3
+	Driver string
+	// Directory of local driver
 	FilePath string
+	// Base url stored on records, e.g. a CDN in front of the bucket.
+	// Defaults to FilePath for local and to the object url for s3.
+	PublicURL string
+	S3        S3
+}
+
+// S3-compatible storage config
+type S3 struct {
+	Endpoint string
+	// Endpoint of presigned upload urls when clients reach storage by another host
+	PresignEndpoint string
+	Region          string
+	Bucket          string
+	AccessKey       string
+	SecretKey       string
+	TimeoutSeconds  int
+}
+
+// Timeline config
+type Timeline struct {
+	MaxSize         int64
+	BackfillSize    int
+	FanoutThreshold int64
+}
+
+// Trend config
+type Trend struct {
+	BucketSeconds   int64
+	WindowBuckets   int
+	BaselineBuckets int
+	MinCount        int64
+	Limit           int
+}
+
+// Message config
+type Message struct {
+	MaxMembers int
+}
+
+// Tweet config
+type Tweet struct {
+	EditWindowSeconds int
+	MaxEdits          int
+}
+
+// Draft config
+type Draft struct {
+	SchedulerEnabled         bool
+	SchedulerIntervalSeconds int
+	BatchSize                int
+	StaleSeconds             int
+}
+
+// Upload config
+type Upload struct {
+	ExpireSeconds int
+	MaxSizeBytes  int64
+}
+
+// Auth config
+type Auth struct {
+	// session: session cookie only, token: bearer access tokens and refresh tokens as well
+	Mode                   string
+	AccessTokenTTLSeconds  int
+	RefreshTokenTTLSeconds int
+	// Kid of the key signing new access tokens
+	SigningKeyID string
+	// HS256 keys by kid, access tokens are accepted while their key stays in the set
+	Keys map[string]string
+}
+
+// Storage quota config, bytes of images a user can store, 0 is unlimited
+type Quota struct {
+	DefaultBytes int64
+	// Quota by role of user, in place of the default
+	RoleBytes map[string]int64
+}
+
+// Orphaned file collector config
+type FileGC struct {
+	Enabled         bool
+	IntervalSeconds int
+	// Unreferenced objects are kept until this old, they may belong to a request in progress.
+	// Identical uploads only share images uploaded within this period.
+	GraceSeconds int
+	// Report orphans without removing them
+	DryRun bool
+	// Pushgateway the collect_files command pushes its counters to, nothing is pushed when empty
+	PushgatewayURL string
+}
+
+// Stream config
+type Stream struct {
+	HeartbeatSeconds int
+	BufferSize       int
+	ReplaySize       int64
+	ReplayTTLSeconds int
 }
 
 // Jaeger
@@ -0,0 +1,9 @@
+package auth
+
+import "github.com/labstack/echo/v4"
+
+// Auth HTTP Handlers interface
+type Handlers interface {
+	Refresh() echo.HandlerFunc
+	Revoke() echo.HandlerFunc
+}
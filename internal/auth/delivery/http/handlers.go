@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Auth handlers
+type AuthHandlers struct {
+	cfg    *config.Config
+	authUC auth.UseCase
+	logger logger.Logger
+}
+
+// NewAuthHandlers Auth handlers constructor
+func NewAuthHandlers(cfg *config.Config, authUC auth.UseCase, logger logger.Logger) auth.Handlers {
+	return &AuthHandlers{cfg: cfg, authUC: authUC, logger: logger}
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange refresh token for a new access token and refresh token, the refresh token cannot be used again
+// @Tags Auth
+// @Accept json
+// @Param body body models.RefreshTokenRequest true "refresh_token"
+// @Produce json
+// @Success 200 {object} models.AuthTokens
+// @Failure 401 {object} httpErrors.RestError
+// @Router /auth/refresh [post]
+func (h *AuthHandlers) Refresh() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "AuthHandlers.Refresh", nil)
+		defer span.End()
+
+		request := &models.RefreshTokenRequest{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tokens, err := h.authUC.Refresh(ctx, request.RefreshToken)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tokens)
+	}
+}
+
+// Revoke godoc
+// @Summary Revoke tokens
+// @Description Revoke refresh token along with every token rotated from the same login and their access tokens
+// @Tags Auth
+// @Accept json
+// @Param body body models.RefreshTokenRequest true "refresh_token"
+// @Produce json
+// @Success 200 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /auth/revoke [post]
+func (h *AuthHandlers) Revoke() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "AuthHandlers.Revoke", nil)
+		defer span.End()
+
+		request := &models.RefreshTokenRequest{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err := h.authUC.Revoke(ctx, request.RefreshToken); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/labstack/echo/v4"
+)
+
+// Map auth routes, the refresh token in the body is the credential
+func MapAuthRoutes(authGroup *echo.Group, h auth.Handlers) {
+	authGroup.POST("/refresh", h.Refresh())
+	authGroup.POST("/revoke", h.Revoke())
+}
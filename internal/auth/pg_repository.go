@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
+)
+
+// Auth repository interface
+type Repository interface {
+	Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error)
+	// Mark active token as rotated and create the next one of its family in one transaction
+	Rotate(ctx context.Context, tokenHash string, next *models.RefreshToken) (*models.RefreshToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
+}
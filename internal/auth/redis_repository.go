@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+)
+
+// Auth Redis repository interface, active refresh token of every token family
+type RedisRepository interface {
+	GetFamilyCtx(ctx context.Context, key string) (*models.RefreshToken, error)
+	SetFamilyCtx(ctx context.Context, key string, seconds int, token *models.RefreshToken) error
+	DeleteFamilyCtx(ctx context.Context, key string) error
+}
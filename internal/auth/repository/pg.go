@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Auth repository
+type authRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) auth.Repository {
+	return &authRepo{db: db}
+}
+
+func (r *authRepo) Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error) {
+	ctx, span := tracer.NewSpan(ctx, "authRepo.Create", nil)
+	defer span.End()
+
+	t := &models.RefreshToken{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createRefreshTokenQuery,
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+	).StructScan(t); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRepo.Create.StructScan")
+	}
+	return t, nil
+}
+
+// Only active tokens not expired yet are rotated, so that every token is used once
+func (r *authRepo) Rotate(ctx context.Context, tokenHash string, next *models.RefreshToken) (*models.RefreshToken, error) {
+	ctx, span := tracer.NewSpan(ctx, "authRepo.Rotate", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRepo.Rotate.BeginTxx")
+	}
+
+	rotated := &models.RefreshToken{}
+	if err = tx.GetContext(ctx, rotated, rotateRefreshTokenQuery, tokenHash); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.Wrap(err, "authRepo.Rotate.GetContext")
+	}
+
+	created := &models.RefreshToken{}
+	if err = tx.QueryRowxContext(
+		ctx,
+		createRefreshTokenQuery,
+		next.ID,
+		rotated.UserID,
+		rotated.FamilyID,
+		next.TokenHash,
+		next.ExpiresAt,
+	).StructScan(created); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.Wrap(err, "authRepo.Rotate.StructScan")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRepo.Rotate.Commit")
+	}
+
+	return created, nil
+}
+
+func (r *authRepo) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	ctx, span := tracer.NewSpan(ctx, "authRepo.GetByHash", nil)
+	defer span.End()
+
+	t := &models.RefreshToken{}
+	if err := r.db.GetContext(ctx, t, getRefreshTokenByHashQuery, tokenHash); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRepo.GetByHash.GetContext")
+	}
+	return t, nil
+}
+
+func (r *authRepo) RevokeFamily(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "authRepo.RevokeFamily", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, revokeFamilyQuery, familyID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "authRepo.RevokeFamily.ExecContext")
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "authRepo.RevokeFamily.RowsAffected")
+	}
+
+	return revoked, nil
+}
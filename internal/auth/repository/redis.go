@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Auth redis repository
+type authRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Auth redis repository constructor
+func NewAuthRedisRepo(redisClient *redis.Client) auth.RedisRepository {
+	return &authRedisRepo{redisClient: redisClient}
+}
+
+// Get active refresh token of family
+func (a *authRedisRepo) GetFamilyCtx(ctx context.Context, key string) (*models.RefreshToken, error) {
+	ctx, span := tracer.NewSpan(ctx, "authRedisRepo.GetFamilyCtx", nil)
+	defer span.End()
+
+	tokenBytes, err := a.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRedisRepo.GetFamilyCtx.redisClient.Get")
+	}
+	token := &models.RefreshToken{}
+	if err = json.Unmarshal(tokenBytes, token); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "authRedisRepo.GetFamilyCtx.json.Unmarshal")
+	}
+	return token, nil
+}
+
+// Set active refresh token of family with duration in seconds
+func (a *authRedisRepo) SetFamilyCtx(ctx context.Context, key string, seconds int, token *models.RefreshToken) error {
+	ctx, span := tracer.NewSpan(ctx, "authRedisRepo.SetFamilyCtx", nil)
+	defer span.End()
+
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "authRedisRepo.SetFamilyCtx.json.Marshal")
+	}
+	if err = a.redisClient.Set(ctx, key, tokenBytes, time.Second*time.Duration(seconds)).Err(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "authRedisRepo.SetFamilyCtx.redisClient.Set")
+	}
+	return nil
+}
+
+func (a *authRedisRepo) DeleteFamilyCtx(ctx context.Context, key string) error {
+	ctx, span := tracer.NewSpan(ctx, "authRedisRepo.DeleteFamilyCtx", nil)
+	defer span.End()
+
+	if err := a.redisClient.Del(ctx, key).Err(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "authRedisRepo.DeleteFamilyCtx.redisClient.Del")
+	}
+	return nil
+}
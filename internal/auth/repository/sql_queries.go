@@ -0,0 +1,16 @@
+package repository
+
+const (
+	createRefreshTokenQuery = `INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at, updated_at)
+							   VALUES ($1, $2, $3, $4, $5, now(), now())
+							   RETURNING *`
+
+	rotateRefreshTokenQuery = `UPDATE refresh_tokens
+							   SET status = 'rotated', updated_at = now()
+							   WHERE token_hash = $1 AND status = 'active' AND expires_at > now()
+							   RETURNING *`
+
+	getRefreshTokenByHashQuery = `SELECT * FROM refresh_tokens WHERE token_hash = $1`
+
+	revokeFamilyQuery = `UPDATE refresh_tokens SET status = 'revoked', updated_at = now() WHERE family_id = $1 AND status = 'active'`
+)
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Auth modes
+const (
+	SessionMode = "session"
+	TokenMode   = "token"
+)
+
+// Auth usecase interface
+type UseCase interface {
+	// Issue access and refresh tokens of a new token family
+	IssueTokens(ctx context.Context, user *models.User) (*models.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	Revoke(ctx context.Context, refreshToken string) error
+	ValidateAccessToken(ctx context.Context, accessToken string) (*utils.Claims, error)
+}
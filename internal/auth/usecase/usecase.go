@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/user"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	familyPrefix = "api-auth-family:"
+	tokenType    = "Bearer"
+	// Refresh tokens are random bytes encoded as base64url
+	refreshTokenBytes = 32
+)
+
+// Auth Usecase
+type authUC struct {
+	cfg       *config.Config
+	authRepo  auth.Repository
+	redisRepo auth.RedisRepository
+	userRepo  user.Repository
+	logger    logger.Logger
+}
+
+// New Usecase
+func NewAuthUseCase(cfg *config.Config, authRepo auth.Repository, redisRepo auth.RedisRepository, userRepo user.Repository, logger logger.Logger) auth.UseCase {
+	return &authUC{
+		cfg:       cfg,
+		authRepo:  authRepo,
+		redisRepo: redisRepo,
+		userRepo:  userRepo,
+		logger:    logger,
+	}
+}
+
+func (u *authUC) IssueTokens(ctx context.Context, user *models.User) (*models.AuthTokens, error) {
+	ctx, span := tracer.NewSpan(ctx, "authUC.IssueTokens", nil)
+	defer span.End()
+
+	refreshToken, tokenHash, err := generateRefreshToken()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewInternalServerError(errors.WithMessage(err, "authUC.IssueTokens.generateRefreshToken"))
+	}
+
+	createdToken, err := u.authRepo.Create(ctx, &models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.UserID,
+		FamilyID:  uuid.New(),
+		TokenHash: tokenHash,
+		ExpiresAt: u.refreshTokenExpiry(),
+	})
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.issue(ctx, user, createdToken, refreshToken)
+}
+
+// Exchange refresh token for new tokens, the refresh token is rotated and cannot be used again.
+// Using a rotated token again means more than one client holds it, so its whole family is revoked.
+func (u *authUC) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	ctx, span := tracer.NewSpan(ctx, "authUC.Refresh", nil)
+	defer span.End()
+
+	nextToken, nextHash, err := generateRefreshToken()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewInternalServerError(errors.WithMessage(err, "authUC.Refresh.generateRefreshToken"))
+	}
+
+	tokenHash := hashRefreshToken(refreshToken)
+	rotatedToken, err := u.authRepo.Rotate(ctx, tokenHash, &models.RefreshToken{
+		ID:        uuid.New(),
+		TokenHash: nextHash,
+		ExpiresAt: u.refreshTokenExpiry(),
+	})
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			u.detectReuse(ctx, tokenHash)
+			return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.Refresh.Rotate"))
+		}
+		return nil, err
+	}
+
+	foundUser, err := u.userRepo.GetByID(ctx, rotatedToken.UserID, rotatedToken.UserID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.Refresh.GetByID"))
+	}
+
+	return u.issue(ctx, foundUser, rotatedToken, nextToken)
+}
+
+// Revoke family of refresh token along with its access tokens, unknown tokens are ignored
+func (u *authUC) Revoke(ctx context.Context, refreshToken string) error {
+	ctx, span := tracer.NewSpan(ctx, "authUC.Revoke", nil)
+	defer span.End()
+
+	token, err := u.authRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if err = u.revokeFamily(ctx, token.FamilyID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// Validate signature and expiry of access token, and that its token family is not revoked.
+// Families missing from redis are refused too, clients get new tokens with their refresh token.
+func (u *authUC) ValidateAccessToken(ctx context.Context, accessToken string) (*utils.Claims, error) {
+	ctx, span := tracer.NewSpan(ctx, "authUC.ValidateAccessToken", nil)
+	defer span.End()
+
+	claims, err := utils.ParseAccessToken(accessToken, u.cfg)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.ValidateAccessToken.ParseAccessToken"))
+	}
+
+	familyID, err := uuid.Parse(claims.FamilyID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.Wrap(err, "authUC.ValidateAccessToken.uuid.Parse"))
+	}
+
+	family, err := u.redisRepo.GetFamilyCtx(ctx, u.getFamilyKey(familyID))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.ValidateAccessToken.GetFamilyCtx"))
+	}
+
+	if family.UserID.String() != claims.ID {
+		err = errors.New("token family of other user")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "authUC.ValidateAccessToken"))
+	}
+
+	return claims, nil
+}
+
+// Cache active refresh token of family and sign access token for it
+func (u *authUC) issue(ctx context.Context, user *models.User, token *models.RefreshToken, refreshToken string) (*models.AuthTokens, error) {
+	if err := u.redisRepo.SetFamilyCtx(ctx, u.getFamilyKey(token.FamilyID), u.cfg.Auth.RefreshTokenTTLSeconds, token); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateAccessToken(user, token.FamilyID, u.cfg)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "authUC.issue.GenerateAccessToken"))
+	}
+
+	return &models.AuthTokens{
+		AccessToken:      accessToken,
+		TokenType:        tokenType,
+		ExpiresIn:        u.cfg.Auth.AccessTokenTTLSeconds,
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: u.cfg.Auth.RefreshTokenTTLSeconds,
+	}, nil
+}
+
+func (u *authUC) detectReuse(ctx context.Context, tokenHash string) {
+	token, err := u.authRepo.GetByHash(ctx, tokenHash)
+	if err != nil || token.Status != models.RefreshTokenRotated {
+		return
+	}
+
+	u.logger.Warnf("authUC.detectReuse: rotated refresh token %s of user %s used again, revoking token family %s",
+		token.ID, token.UserID, token.FamilyID)
+	if err = u.revokeFamily(ctx, token.FamilyID); err != nil {
+		u.logger.Errorf("authUC.detectReuse.revokeFamily: %v", err)
+	}
+}
+
+func (u *authUC) revokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	if _, err := u.authRepo.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return u.redisRepo.DeleteFamilyCtx(ctx, u.getFamilyKey(familyID))
+}
+
+func (u *authUC) refreshTokenExpiry() time.Time {
+	return time.Now().Add(time.Duration(u.cfg.Auth.RefreshTokenTTLSeconds) * time.Second)
+}
+
+func (u *authUC) getFamilyKey(familyID uuid.UUID) string {
+	return familyPrefix + familyID.String()
+}
+
+// Generate opaque refresh token, only its hash is stored
+func generateRefreshToken() (string, string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", errors.Wrap(err, "rand.Read")
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
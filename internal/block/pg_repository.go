@@ -0,0 +1,19 @@
+package block
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Block(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error
+	GetBlocked(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	IsBlocked(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (bool, error)
+	Unblock(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error
+	Mute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error
+	GetMuted(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	Unmute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error
+}
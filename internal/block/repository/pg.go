@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/block"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Block repository
+type blockRepo struct {
+	db *sqlx.DB
+}
+
+func NewBlockRepository(db *sqlx.DB) block.Repository {
+	return &blockRepo{db: db}
+}
+
+// Block an user and drop pending follow requests between both users
+func (r *blockRepo) Block(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.Block", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "blockRepo.Block.BeginTxx")
+	}
+
+	if _, err = tx.ExecContext(ctx, blockQuery, blocker, blocked); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return errors.WithMessage(err, "blockRepo.Block.ExecContext.blockQuery")
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteFollowRequestsQuery, blocker, blocked); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return errors.Wrap(err, "blockRepo.Block.ExecContext.deleteFollowRequestsQuery")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "blockRepo.Block.Commit")
+	}
+
+	return nil
+}
+
+// Get users blocked by user
+func (r *blockRepo) GetBlocked(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.GetBlocked", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getBlockedTotal, userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "blockRepo.GetBlocked.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getBlocked, userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "blockRepo.GetBlocked.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
+	return &models.UsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+// Check if either user blocked the other
+func (r *blockRepo) IsBlocked(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.IsBlocked", nil)
+	defer span.End()
+
+	var isBlocked bool
+	if err := r.db.GetContext(ctx, &isBlocked, checkBlocked, userID.String(), otherID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "blockRepo.IsBlocked.GetContext")
+	}
+
+	return isBlocked, nil
+}
+
+func (r *blockRepo) Unblock(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.Unblock", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, unblockQuery, blocker, blocked)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "blockRepo.Unblock.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "blockRepo.Unblock.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "blockRepo.Unblock.rowsAffected")
+	}
+
+	return nil
+}
+
+// Mute an user, muting twice is a no-op
+func (r *blockRepo) Mute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.Mute", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, muteQuery, muter, muted); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "blockRepo.Mute.ExecContext")
+	}
+
+	return nil
+}
+
+// Get users muted by user
+func (r *blockRepo) GetMuted(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.GetMuted", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getMutedTotal, userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "blockRepo.GetMuted.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getMuted, userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "blockRepo.GetMuted.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
+	return &models.UsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+func (r *blockRepo) Unmute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockRepo.Unmute", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, unmuteQuery, muter, muted)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "blockRepo.Unmute.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "blockRepo.Unmute.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "blockRepo.Unmute.rowsAffected")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+const (
+	blockQuery = `INSERT INTO blocks (blocker_id, blocked_id, created_at)
+				  VALUES ($1, $2, now())
+				  ON CONFLICT DO NOTHING`
+
+	deleteFollowRequestsQuery = `DELETE FROM follow_requests
+								 WHERE (requester_id = $1 AND target_id = $2)
+								 OR (requester_id = $2 AND target_id = $1)`
+
+	checkBlocked = `SELECT EXISTS (SELECT 1 FROM blocks
+					WHERE (blocker_id = $1 AND blocked_id = $2)
+					OR (blocker_id = $2 AND blocked_id = $1))`
+
+	getBlockedTotal = `SELECT COUNT(b.blocked_id)
+					   FROM blocks b
+					   WHERE b.blocker_id = $1`
+
+	getBlocked = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
+				  u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date
+				  FROM users u
+				  INNER JOIN blocks b ON b.blocked_id = u.user_id
+				  WHERE b.blocker_id = $1
+				  AND ($2::text IS NULL OR (u.name, u.user_id) > ($2::text, $3::text::uuid))
+				  ORDER BY u.name, u.user_id OFFSET $4 LIMIT $5
+				  `
+
+	unblockQuery = `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`
+
+	muteQuery = `INSERT INTO mutes (muter_id, muted_id, created_at)
+				 VALUES ($1, $2, now())
+				 ON CONFLICT DO NOTHING`
+
+	getMutedTotal = `SELECT COUNT(m.muted_id)
+					 FROM mutes m
+					 WHERE m.muter_id = $1`
+
+	getMuted = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
+				u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+				EXISTS (SELECT 1 FROM follows f where u.user_id = f.following_id and f.follower_id = $1) AS is_following
+				FROM users u
+				INNER JOIN mutes m ON m.muted_id = u.user_id
+				WHERE m.muter_id = $1
+				AND ($2::text IS NULL OR (u.name, u.user_id) > ($2::text, $3::text::uuid))
+				ORDER BY u.name, u.user_id OFFSET $4 LIMIT $5
+				`
+
+	unmuteQuery = `DELETE FROM mutes WHERE muter_id = $1 AND muted_id = $2`
+)
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/block"
+	"github.com/JamesHsu333/go-twitter/internal/follow"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Block Usecase
+type blockUC struct {
+	cfg       *config.Config
+	blockRepo block.Repository
+	followUC  follow.UseCase
+	logger    logger.Logger
+}
+
+// New Usecase
+func NewBlockUseCase(cfg *config.Config, blockRepo block.Repository, followUC follow.UseCase, logger logger.Logger) block.UseCase {
+	return &blockUC{
+		cfg:       cfg,
+		blockRepo: blockRepo,
+		followUC:  followUC,
+		logger:    logger,
+	}
+}
+
+// Block user, follows in both directions are removed
+func (u *blockUC) Block(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.Block", nil)
+	defer span.End()
+
+	if blocker == blocked {
+		err := errors.New("users cannot block themselves")
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewBadRequestError(errors.WithMessage(err, "blockUC.Block"))
+	}
+
+	if err := u.blockRepo.Block(ctx, blocker, blocked); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("blockUC.Block.Block: %v", err)
+		return err
+	}
+
+	// Go through the follow usecase so caches and home timelines are trimmed too
+	u.unfollow(ctx, blocker, blocked)
+	u.unfollow(ctx, blocked, blocker)
+
+	return nil
+}
+
+func (u *blockUC) GetBlocked(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.GetBlocked", nil)
+	defer span.End()
+
+	return u.blockRepo.GetBlocked(ctx, userID, pq)
+}
+
+func (u *blockUC) Unblock(ctx context.Context, blocker uuid.UUID, blocked uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.Unblock", nil)
+	defer span.End()
+
+	return u.blockRepo.Unblock(ctx, blocker, blocked)
+}
+
+// Mute user, tweets of muted user are left out of feeds of muter
+func (u *blockUC) Mute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.Mute", nil)
+	defer span.End()
+
+	if muter == muted {
+		err := errors.New("users cannot mute themselves")
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewBadRequestError(errors.WithMessage(err, "blockUC.Mute"))
+	}
+
+	return u.blockRepo.Mute(ctx, muter, muted)
+}
+
+func (u *blockUC) GetMuted(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.GetMuted", nil)
+	defer span.End()
+
+	return u.blockRepo.GetMuted(ctx, userID, pq)
+}
+
+func (u *blockUC) Unmute(ctx context.Context, muter uuid.UUID, muted uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.Unmute", nil)
+	defer span.End()
+
+	return u.blockRepo.Unmute(ctx, muter, muted)
+}
+
+// Remove follow of follower to following if there is one
+func (u *blockUC) unfollow(ctx context.Context, follower uuid.UUID, following uuid.UUID) {
+	ctx, span := tracer.NewSpan(ctx, "blockUC.unfollow", nil)
+	defer span.End()
+
+	if err := u.followUC.Delete(ctx, follower, following); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("blockUC.unfollow.Delete: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package bookmark
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Bookmark(ctx context.Context, userID uuid.UUID, tweetID uint64, folderID *uint64) error
+	GetBookmarkedTweets(ctx context.Context, userID uuid.UUID, folderID *uint64, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	Delete(ctx context.Context, userID uuid.UUID, tweetID uint64) error
+	CreateFolder(ctx context.Context, folder *models.BookmarkFolder) (*models.BookmarkFolder, error)
+	GetFolders(ctx context.Context, userID uuid.UUID) ([]*models.BookmarkFolder, error)
+	DeleteFolder(ctx context.Context, userID uuid.UUID, folderID uint64) error
+}
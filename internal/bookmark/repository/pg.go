@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/bookmark"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+type bookmarkRepo struct {
+	db *sqlx.DB
+}
+
+func NewBookmarkRepository(db *sqlx.DB) bookmark.Repository {
+	return &bookmarkRepo{db: db}
+}
+
+// Bookmark tweet, bookmarking it again moves it to the given folder
+func (r *bookmarkRepo) Bookmark(ctx context.Context, userID uuid.UUID, tweetID uint64, folderID *uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.Bookmark", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, bookmarkQuery, userID.String(), tweetID, folderID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.Bookmark.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.Bookmark.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "bookmarkRepo.Bookmark.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *bookmarkRepo) GetBookmarkedTweets(ctx context.Context, userID uuid.UUID, folderID *uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.GetBookmarkedTweets", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getTotalBookmarkedTweets, userID.String(), folderID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "bookmarkRepo.GetBookmarkedTweets.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getBookmarkedTweets, userID.String(), folderID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "bookmarkRepo.GetBookmarkedTweets.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
+
+func (r *bookmarkRepo) Delete(ctx context.Context, userID uuid.UUID, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.Delete", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteQuery, userID.String(), tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.Delete.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "bookmarkRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *bookmarkRepo) CreateFolder(ctx context.Context, folder *models.BookmarkFolder) (*models.BookmarkFolder, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.CreateFolder", nil)
+	defer span.End()
+
+	f := &models.BookmarkFolder{}
+	if err := r.db.QueryRowxContext(ctx, createFolderQuery, folder.UserID.String(), folder.Name).StructScan(f); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "bookmarkRepo.CreateFolder.StructScan")
+	}
+	return f, nil
+}
+
+func (r *bookmarkRepo) GetFolders(ctx context.Context, userID uuid.UUID) ([]*models.BookmarkFolder, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.GetFolders", nil)
+	defer span.End()
+
+	var folders = make([]*models.BookmarkFolder, 0)
+	if err := r.db.SelectContext(ctx, &folders, getFolders, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "bookmarkRepo.GetFolders.SelectContext")
+	}
+
+	return folders, nil
+}
+
+func (r *bookmarkRepo) DeleteFolder(ctx context.Context, userID uuid.UUID, folderID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkRepo.DeleteFolder", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteFolderQuery, userID.String(), folderID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.DeleteFolder.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "bookmarkRepo.DeleteFolder.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "bookmarkRepo.DeleteFolder.rowsAffected")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+const (
+	bookmarkQuery = `INSERT INTO tweets_bookmarks (user_id, tweet_id, folder_id, created_at)
+					 SELECT $1, $2, $3, now()
+					 WHERE $3::bigint IS NULL OR EXISTS (SELECT 1 FROM bookmark_folders bf WHERE bf.id = $3 AND bf.user_id = $1)
+					 ON CONFLICT (user_id, tweet_id) DO UPDATE SET folder_id = EXCLUDED.folder_id`
+
+	getTotalBookmarkedTweets = `SELECT COUNT(bb.tweet_id)
+								FROM tweets_bookmarks bb
+								INNER JOIN tweets t ON t.id = bb.tweet_id
+								INNER JOIN users u ON t.user_id = u.user_id
+								WHERE bb.user_id = $1
+								AND ($2::bigint IS NULL OR bb.folder_id = $2)
+								AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+								AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))`
+
+	getBookmarkedTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						   u.user_id, u.name, u.user_name, u.about, u.avatar,
+						   COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						   EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						   EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						   true AS already_bookmarked,
+						   (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						   (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						   COALESCE(t.conversation_id, t.id) AS conversation_id
+						   FROM tweets t
+						   INNER JOIN users u ON t.user_id = u.user_id
+						   LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+						   LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						   LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						   WHERE t.id IN (SELECT bb.tweet_id FROM tweets_bookmarks bb WHERE bb.user_id = $1 AND ($2::bigint IS NULL OR bb.folder_id = $2))
+						   AND ($3::text IS NULL OR (t.created_at, t.id) < ($3::text::timestamptz, $4::text::bigint))
+						   AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						   GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						   u.user_id, u.name, u.user_name, u.about, u.avatar
+						   ORDER BY t.created_at desc, t.id desc
+						   OFFSET $5 LIMIT $6`
+
+	deleteQuery = `DELETE FROM tweets_bookmarks WHERE user_id = $1 AND tweet_id = $2`
+
+	createFolderQuery = `INSERT INTO bookmark_folders (user_id, name, created_at)
+						 VALUES ($1, $2, now())
+						 RETURNING *`
+
+	getFolders = `SELECT bf.id, bf.user_id, bf.name, bf.created_at,
+				  (SELECT COUNT(bb.tweet_id) FROM tweets_bookmarks bb WHERE bb.folder_id = bf.id) AS bookmarks
+				  FROM bookmark_folders bf
+				  WHERE bf.user_id = $1
+				  ORDER BY bf.name`
+
+	deleteFolderQuery = `DELETE FROM bookmark_folders WHERE user_id = $1 AND id = $2`
+)
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/bookmark"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+type bookmarkUC struct {
+	cfg          *config.Config
+	bookmarkRepo bookmark.Repository
+	tweetRepo    tweet.Repository
+	logger       logger.Logger
+}
+
+func NewBookmarkUseCase(cfg *config.Config, bookmarkRepo bookmark.Repository, tweetRepo tweet.Repository, logger logger.Logger) bookmark.UseCase {
+	return &bookmarkUC{
+		cfg:          cfg,
+		bookmarkRepo: bookmarkRepo,
+		tweetRepo:    tweetRepo,
+		logger:       logger,
+	}
+}
+
+// Bookmark tweet, unlike likes bookmarks are never shown to other users
+func (u *bookmarkUC) Bookmark(ctx context.Context, userID uuid.UUID, tweetID uint64, folderID *uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.Bookmark", nil)
+	defer span.End()
+
+	if _, err := u.tweetRepo.GetTweetByID(ctx, userID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	return u.bookmarkRepo.Bookmark(ctx, userID, tweetID, folderID)
+}
+
+func (u *bookmarkUC) GetBookmarkedTweets(ctx context.Context, userID uuid.UUID, folderID *uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.GetBookmarkedTweets", nil)
+	defer span.End()
+
+	return u.bookmarkRepo.GetBookmarkedTweets(ctx, userID, folderID, pq)
+}
+
+func (u *bookmarkUC) Delete(ctx context.Context, userID uuid.UUID, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.Delete", nil)
+	defer span.End()
+
+	return u.bookmarkRepo.Delete(ctx, userID, tweetID)
+}
+
+func (u *bookmarkUC) CreateFolder(ctx context.Context, folder *models.BookmarkFolder) (*models.BookmarkFolder, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.CreateFolder", nil)
+	defer span.End()
+
+	if err := utils.ValidateStruct(ctx, folder); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "bookmarkUC.CreateFolder.ValidateStruct"))
+	}
+
+	return u.bookmarkRepo.CreateFolder(ctx, folder)
+}
+
+func (u *bookmarkUC) GetFolders(ctx context.Context, userID uuid.UUID) (*models.BookmarkFoldersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.GetFolders", nil)
+	defer span.End()
+
+	folders, err := u.bookmarkRepo.GetFolders(ctx, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return &models.BookmarkFoldersList{Folders: folders}, nil
+}
+
+// Delete folder, its bookmarks move back to the default collection
+func (u *bookmarkUC) DeleteFolder(ctx context.Context, userID uuid.UUID, folderID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "bookmarkUC.DeleteFolder", nil)
+	defer span.End()
+
+	return u.bookmarkRepo.DeleteFolder(ctx, userID, folderID)
+}
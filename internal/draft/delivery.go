@@ -0,0 +1,13 @@
+package draft
+
+import "github.com/labstack/echo/v4"
+
+// Draft HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetDraftByID() echo.HandlerFunc
+	GetDrafts() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	Publish() echo.HandlerFunc
+}
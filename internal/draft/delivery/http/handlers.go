@@ -0,0 +1,306 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/draft"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Draft handlers
+type DraftHandlers struct {
+	cfg     *config.Config
+	draftUC draft.UseCase
+	fileUC  file.UseCase
+	logger  logger.Logger
+}
+
+// NewDraftHandlers Draft handlers constructor
+func NewDraftHandlers(cfg *config.Config, draftUC draft.UseCase, fileUC file.UseCase, logger logger.Logger) draft.Handlers {
+	return &DraftHandlers{cfg: cfg, draftUC: draftUC, fileUC: fileUC, logger: logger}
+}
+
+// Create godoc
+// @Summary Create new draft
+// @Description Save tweet draft, scheduled for publishing when publish_at is set, returns draft
+// @Tags Draft
+// @Accept file formData file true "Body with image file"
+// @Produce json
+// @Success 201 {object} models.Draft
+// @Failure 400 {object} httpErrors.RestError
+// @Router /drafts [post]
+func (h *DraftHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.Create", nil)
+		defer span.End()
+
+		d := &models.Draft{}
+		if err := utils.ReadRequest(c, d); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		image, err := utils.ReadImage(c, "image")
+		if err != nil {
+			if !strings.Contains(err.Error(), "no such file") {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
+		if image != nil {
+			file, err := image.Open()
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+			defer file.Close()
+
+			binaryImage := bytes.NewBuffer(nil)
+			if _, err = io.Copy(binaryImage, file); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			if _, err = utils.CheckImageFileContentType(binaryImage.Bytes()); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			uploadedImage, err := h.fileUC.PutImage(ctx, binaryImage.Bytes())
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			d.Image = &uploadedImage.URL
+		}
+
+		createdDraft, err := h.draftUC.Create(ctx, d)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdDraft)
+	}
+}
+
+// GetDraftByID godoc
+// @Summary Get draft
+// @Description Get draft of current user
+// @Tags Draft
+// @Accept json
+// @Param id path int true "draft_id"
+// @Produce json
+// @Success 200 {object} models.Draft
+// @Failure 404 {object} httpErrors.RestError
+// @Router /drafts/{id} [get]
+func (h *DraftHandlers) GetDraftByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.GetDraftByID", nil)
+		defer span.End()
+
+		draftID, err := strconv.ParseUint(c.Param("draft_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		d, err := h.draftUC.GetDraftByID(ctx, draftID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, d)
+	}
+}
+
+// GetDrafts godoc
+// @Summary Get drafts
+// @Description Get drafts of current user not published yet, scheduled ones first by publish time
+// @Tags Draft
+// @Accept json
+// @Param scheduled query bool false "only scheduled drafts when true, only unscheduled drafts when false"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.DraftsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /drafts [get]
+func (h *DraftHandlers) GetDrafts() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.GetDrafts", nil)
+		defer span.End()
+
+		var scheduled *bool
+		if param := c.QueryParam("scheduled"); param != "" {
+			value, err := strconv.ParseBool(param)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+			}
+			scheduled = &value
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		draftsList, err := h.draftUC.GetDrafts(ctx, scheduled, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, draftsList)
+	}
+}
+
+// Update godoc
+// @Summary Update draft
+// @Description Update text or publish time of draft not published yet, a failed draft is queued again, an unknown draft may have been published and cannot be edited
+// @Tags Draft
+// @Accept json
+// @Param id path int true "draft_id"
+// @Produce json
+// @Success 200 {object} models.Draft
+// @Failure 400 {object} httpErrors.RestError
+// @Router /drafts/{id} [patch]
+func (h *DraftHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.Update", nil)
+		defer span.End()
+
+		draftID, err := strconv.ParseUint(c.Param("draft_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Validated by the usecase once merged with the stored draft
+		d := &models.Draft{}
+		if err = c.Bind(d); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		d.ID = draftID
+
+		updatedDraft, err := h.draftUC.Update(ctx, d)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedDraft)
+	}
+}
+
+// Delete godoc
+// @Summary Delete draft
+// @Description Delete draft not published yet, cancelling it when scheduled. The image of an unknown draft is kept, its tweet may use it
+// @Tags Draft
+// @Accept json
+// @Param id path int true "draft_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /drafts/{id} [delete]
+func (h *DraftHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.Delete", nil)
+		defer span.End()
+
+		draftID, err := strconv.ParseUint(c.Param("draft_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		d, err := h.draftUC.GetDraftByID(ctx, draftID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.draftUC.Delete(ctx, draftID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// The tweet of an unknown draft may share the image, it is left to the collector
+		if d.Image != nil && d.Status != models.DraftUnknown {
+			if err = h.fileUC.RemoveImage(ctx, d.UserID, *d.Image); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+			}
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Publish godoc
+// @Summary Publish draft
+// @Description Publish draft now, returns tweet
+// @Tags Draft
+// @Accept json
+// @Param id path int true "draft_id"
+// @Produce json
+// @Success 201 {object} models.Tweet
+// @Failure 404 {object} httpErrors.RestError
+// @Router /drafts/{id}/publish [post]
+func (h *DraftHandlers) Publish() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "DraftHandlers.Publish", nil)
+		defer span.End()
+
+		draftID, err := strconv.ParseUint(c.Param("draft_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdTweet, err := h.draftUC.Publish(ctx, draftID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdTweet)
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/draft"
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map draft routes
+func MapDraftRoutes(draftGroup *echo.Group, h draft.Handlers, mw *middleware.MiddlewareManager) {
+	draftGroup.Use(mw.AuthSessionMiddleware)
+	draftGroup.GET("", h.GetDrafts())
+	draftGroup.POST("", h.Create(), mw.CSRF)
+	draftGroup.GET("/:draft_id", h.GetDraftByID())
+	draftGroup.PATCH("/:draft_id", h.Update(), mw.CSRF)
+	draftGroup.DELETE("/:draft_id", h.Delete(), mw.CSRF)
+	draftGroup.POST("/:draft_id/publish", h.Publish(), mw.CSRF)
+}
@@ -0,0 +1,23 @@
+package draft
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Draft repository interface
+type Repository interface {
+	Create(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	GetDraftByID(ctx context.Context, userID uuid.UUID, draftID uint64) (*models.Draft, error)
+	GetDrafts(ctx context.Context, userID uuid.UUID, scheduled *bool, pq *utils.PaginationQuery) (*models.DraftsList, error)
+	Update(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	Delete(ctx context.Context, userID uuid.UUID, draftID uint64) error
+	ClaimDraft(ctx context.Context, userID uuid.UUID, draftID uint64) (*models.Draft, error)
+	ClaimDueDrafts(ctx context.Context, limit int) ([]*models.Draft, error)
+	MarkPublished(ctx context.Context, draftID uint64, tweetID uint64) error
+	MarkFailed(ctx context.Context, draftID uint64) error
+	MarkStaleDrafts(ctx context.Context, staleSeconds int) (int64, error)
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/draft"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Draft repository
+type draftRepo struct {
+	db *sqlx.DB
+}
+
+func NewDraftRepository(db *sqlx.DB) draft.Repository {
+	return &draftRepo{db: db}
+}
+
+func (r *draftRepo) Create(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.Create", nil)
+	defer span.End()
+
+	d := &models.Draft{}
+	if err := r.db.QueryRowxContext(ctx, createDraftQuery, &draft.UserID, &draft.Text, &draft.Image, draft.PublishAt).StructScan(d); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.Create.StructScan")
+	}
+	return d, nil
+}
+
+func (r *draftRepo) GetDraftByID(ctx context.Context, userID uuid.UUID, draftID uint64) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.GetDraftByID", nil)
+	defer span.End()
+
+	d := &models.Draft{}
+	if err := r.db.QueryRowxContext(ctx, getDraftQuery, draftID, userID).StructScan(d); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.GetDraftByID.StructScan")
+	}
+	return d, nil
+}
+
+// Get drafts not published yet, scheduled ones first by publish time.
+// A nil scheduled returns both plain and scheduled drafts.
+func (r *draftRepo) GetDrafts(ctx context.Context, userID uuid.UUID, scheduled *bool, pq *utils.PaginationQuery) (*models.DraftsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.GetDrafts", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getDraftsTotal, userID, scheduled); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.GetDrafts.GetContext.getDraftsTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.DraftsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Drafts:     make([]*models.Draft, 0),
+		}, nil
+	}
+
+	var drafts = make([]*models.Draft, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &drafts, getDrafts, userID, scheduled, pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.GetDrafts.SelectContext")
+	}
+
+	return &models.DraftsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Drafts:     drafts,
+	}, nil
+}
+
+// Update draft not published yet, a failed draft goes back to pending
+func (r *draftRepo) Update(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.Update", nil)
+	defer span.End()
+
+	d := &models.Draft{}
+	if err := r.db.GetContext(ctx, d, updateDraftQuery, draft.ID, draft.UserID, &draft.Text, draft.PublishAt); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.Update.GetContext")
+	}
+
+	return d, nil
+}
+
+func (r *draftRepo) Delete(ctx context.Context, userID uuid.UUID, draftID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.Delete", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteDraftQuery, draftID, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "draftRepo.Delete.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "draftRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "draftRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// Mark draft of user as publishing, only one caller can claim a draft
+func (r *draftRepo) ClaimDraft(ctx context.Context, userID uuid.UUID, draftID uint64) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.ClaimDraft", nil)
+	defer span.End()
+
+	d := &models.Draft{}
+	if err := r.db.GetContext(ctx, d, claimDraftQuery, draftID, userID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.ClaimDraft.GetContext")
+	}
+
+	return d, nil
+}
+
+// Mark due scheduled drafts as publishing.
+// Rows locked by another replica are skipped, so each draft is claimed once.
+func (r *draftRepo) ClaimDueDrafts(ctx context.Context, limit int) ([]*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.ClaimDueDrafts", nil)
+	defer span.End()
+
+	var drafts = make([]*models.Draft, 0, limit)
+	if err := r.db.SelectContext(ctx, &drafts, claimDueDraftsQuery, limit); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "draftRepo.ClaimDueDrafts.SelectContext")
+	}
+
+	return drafts, nil
+}
+
+func (r *draftRepo) MarkPublished(ctx context.Context, draftID uint64, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.MarkPublished", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, markPublishedQuery, draftID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "draftRepo.MarkPublished.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *draftRepo) MarkFailed(ctx context.Context, draftID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.MarkFailed", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, markFailedQuery, draftID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "draftRepo.MarkFailed.ExecContext")
+	}
+
+	return nil
+}
+
+// Mark drafts stuck in publishing, e.g. after a crash, as unknown.
+// Their tweet may have been created already, so unlike failed drafts they are never claimed again.
+func (r *draftRepo) MarkStaleDrafts(ctx context.Context, staleSeconds int) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftRepo.MarkStaleDrafts", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, markStaleDraftsQuery, staleSeconds)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.WithMessage(err, "draftRepo.MarkStaleDrafts.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "draftRepo.MarkStaleDrafts.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+const (
+	createDraftQuery = `INSERT INTO tweet_drafts (user_id, text, image, publish_at, status, created_at, updated_at)
+						VALUES ($1, $2, $3, $4, 'pending', now(), now())
+						RETURNING *`
+
+	getDraftQuery = `SELECT * FROM tweet_drafts WHERE id = $1 AND user_id = $2`
+
+	getDraftsTotal = `SELECT COUNT(id) FROM tweet_drafts
+					  WHERE user_id = $1 AND status IN ('pending', 'failed', 'unknown')
+					  AND ($2::boolean IS NULL OR (publish_at IS NOT NULL) = $2)`
+
+	getDrafts = `SELECT * FROM tweet_drafts
+				 WHERE user_id = $1 AND status IN ('pending', 'failed', 'unknown')
+				 AND ($2::boolean IS NULL OR (publish_at IS NOT NULL) = $2)
+				 ORDER BY publish_at NULLS LAST, updated_at desc, id desc
+				 OFFSET $3 LIMIT $4`
+
+	updateDraftQuery = `UPDATE tweet_drafts
+						SET text = $3, publish_at = $4, status = 'pending', updated_at = now()
+						WHERE id = $1 AND user_id = $2 AND status IN ('pending', 'failed')
+						RETURNING *`
+
+	deleteDraftQuery = `DELETE FROM tweet_drafts WHERE id = $1 AND user_id = $2 AND status IN ('pending', 'failed', 'unknown')`
+
+	claimDraftQuery = `UPDATE tweet_drafts
+					   SET status = 'publishing', updated_at = now()
+					   WHERE id = $1 AND user_id = $2 AND status IN ('pending', 'failed')
+					   RETURNING *`
+
+	claimDueDraftsQuery = `UPDATE tweet_drafts d
+						   SET status = 'publishing', updated_at = now()
+						   FROM (SELECT id FROM tweet_drafts
+								WHERE status = 'pending' AND publish_at IS NOT NULL AND publish_at <= now()
+								ORDER BY publish_at
+								LIMIT $1
+								FOR UPDATE SKIP LOCKED
+								) __d
+						   WHERE d.id = __d.id
+						   RETURNING d.*`
+
+	markPublishedQuery = `UPDATE tweet_drafts SET status = 'published', tweet_id = $2, updated_at = now() WHERE id = $1`
+
+	markFailedQuery = `UPDATE tweet_drafts SET status = 'failed', updated_at = now() WHERE id = $1`
+
+	markStaleDraftsQuery = `UPDATE tweet_drafts
+							SET status = 'unknown', updated_at = now()
+							WHERE status = 'publishing' AND updated_at < now() - $1 * interval '1 second'`
+)
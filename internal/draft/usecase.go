@@ -0,0 +1,19 @@
+package draft
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Draft usecase interface
+type UseCase interface {
+	Create(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	GetDraftByID(ctx context.Context, draftID uint64) (*models.Draft, error)
+	GetDrafts(ctx context.Context, scheduled *bool, pq *utils.PaginationQuery) (*models.DraftsList, error)
+	Update(ctx context.Context, draft *models.Draft) (*models.Draft, error)
+	Delete(ctx context.Context, draftID uint64) error
+	Publish(ctx context.Context, draftID uint64) (*models.Tweet, error)
+	RunScheduler(ctx context.Context)
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/draft"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
+	"github.com/JamesHsu333/go-twitter/internal/user"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// Draft Usecase
+type draftUC struct {
+	cfg       *config.Config
+	draftRepo draft.Repository
+	userRepo  user.Repository
+	tweetUC   tweet.UseCase
+	logger    logger.Logger
+}
+
+// New Usecase
+func NewDraftUseCase(cfg *config.Config, draftRepo draft.Repository, userRepo user.Repository, tweetUC tweet.UseCase, logger logger.Logger) draft.UseCase {
+	return &draftUC{
+		cfg:       cfg,
+		draftRepo: draftRepo,
+		userRepo:  userRepo,
+		tweetUC:   tweetUC,
+		logger:    logger,
+	}
+}
+
+// Save new draft of current user, scheduled when publish_at is set
+func (u *draftUC) Create(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.Create", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.Create.GetUserFromCtx"))
+	}
+
+	draft.UserID = self.UserID
+	if err = u.validateDraft(ctx, draft); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "draftUC.Create.validateDraft"))
+	}
+
+	return u.draftRepo.Create(ctx, draft)
+}
+
+func (u *draftUC) GetDraftByID(ctx context.Context, draftID uint64) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.GetDraftByID", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.GetDraftByID.GetUserFromCtx"))
+	}
+
+	return u.draftRepo.GetDraftByID(ctx, self.UserID, draftID)
+}
+
+// Get pending, failed and unknown drafts of current user
+func (u *draftUC) GetDrafts(ctx context.Context, scheduled *bool, pq *utils.PaginationQuery) (*models.DraftsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.GetDrafts", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.GetDrafts.GetUserFromCtx"))
+	}
+
+	return u.draftRepo.GetDrafts(ctx, self.UserID, scheduled, pq)
+}
+
+// Update text or publish time of draft, fields left empty are kept
+func (u *draftUC) Update(ctx context.Context, draft *models.Draft) (*models.Draft, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.Update", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.Update.GetUserFromCtx"))
+	}
+
+	existing, err := u.draftRepo.GetDraftByID(ctx, self.UserID, draft.ID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	if err = checkEditable(existing); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "draftUC.Update.checkEditable"))
+	}
+
+	if draft.Text != "" {
+		existing.Text = draft.Text
+	}
+	if draft.PublishAt != nil {
+		existing.PublishAt = draft.PublishAt
+	}
+
+	if err = u.validateDraft(ctx, existing); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "draftUC.Update.validateDraft"))
+	}
+
+	return u.draftRepo.Update(ctx, existing)
+}
+
+// Delete draft, cancelling it when scheduled
+func (u *draftUC) Delete(ctx context.Context, draftID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.Delete", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.Delete.GetUserFromCtx"))
+	}
+
+	return u.draftRepo.Delete(ctx, self.UserID, draftID)
+}
+
+// Publish draft of current user now
+func (u *draftUC) Publish(ctx context.Context, draftID uint64) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.Publish", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "draftUC.Publish.GetUserFromCtx"))
+	}
+
+	d, err := u.draftRepo.ClaimDraft(ctx, self.UserID, draftID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.publishDraft(ctx, d)
+}
+
+// Publish due scheduled drafts until ctx is done.
+// Drafts are claimed before publishing, so with the scheduler running on
+// several replicas every draft is published at most once.
+func (u *draftUC) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(u.cfg.Draft.SchedulerIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.publishDueDrafts(ctx)
+		}
+	}
+}
+
+func (u *draftUC) publishDueDrafts(ctx context.Context) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.publishDueDrafts", nil)
+	defer span.End()
+
+	stale, err := u.draftRepo.MarkStaleDrafts(ctx, u.cfg.Draft.StaleSeconds)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("draftUC.publishDueDrafts.MarkStaleDrafts: %v", err)
+	}
+	if stale > 0 {
+		u.logger.Warnf("draftUC.publishDueDrafts: %d drafts stuck in publishing marked as unknown", stale)
+	}
+
+	for {
+		drafts, err := u.draftRepo.ClaimDueDrafts(ctx, u.cfg.Draft.BatchSize)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			u.logger.Errorf("draftUC.publishDueDrafts.ClaimDueDrafts: %v", err)
+			return
+		}
+
+		for _, d := range drafts {
+			author, err := u.userRepo.GetByID(ctx, d.UserID, d.UserID)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				u.logger.Errorf("draftUC.publishDueDrafts.GetByID: %v", err)
+				u.markFailed(ctx, d.ID)
+				continue
+			}
+
+			if _, err = u.publishDraft(context.WithValue(ctx, utils.UserCtxKey{}, author), d); err != nil {
+				tracer.AddSpanError(span, err)
+				u.logger.Errorf("draftUC.publishDueDrafts.publishDraft: draft %d: %v", d.ID, err)
+			}
+		}
+
+		if len(drafts) < u.cfg.Draft.BatchSize {
+			return
+		}
+	}
+}
+
+// Create the tweet of a claimed draft as the user in ctx, a draft failing to publish is not retried.
+// A draft whose tweet was created stays publishing when marking it fails, it becomes unknown rather than failed
+// once stale, so it is never published twice.
+func (u *draftUC) publishDraft(ctx context.Context, d *models.Draft) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "draftUC.publishDraft", nil)
+	defer span.End()
+
+	createdTweet, err := u.tweetUC.Create(ctx, &models.Tweet{Text: d.Text, Image: d.Image})
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.markFailed(ctx, d.ID)
+		return nil, err
+	}
+
+	if err = u.draftRepo.MarkPublished(ctx, d.ID, createdTweet.ID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("draftUC.publishDraft.MarkPublished: %v", err)
+	}
+
+	return createdTweet, nil
+}
+
+func (u *draftUC) markFailed(ctx context.Context, draftID uint64) {
+	if err := u.draftRepo.MarkFailed(ctx, draftID); err != nil {
+		u.logger.Errorf("draftUC.markFailed.MarkFailed: %v", err)
+	}
+}
+
+func (u *draftUC) validateDraft(ctx context.Context, draft *models.Draft) error {
+	if err := utils.ValidateStruct(ctx, draft); err != nil {
+		return err
+	}
+	if draft.PublishAt != nil && !draft.PublishAt.After(time.Now()) {
+		return errors.New("publish_at must be in the future")
+	}
+	return nil
+}
+
+// Drafts being, already or possibly published cannot be changed
+func checkEditable(draft *models.Draft) error {
+	switch draft.Status {
+	case models.DraftPublishing, models.DraftPublished, models.DraftUnknown:
+		return errors.Errorf("draft %d is %s", draft.ID, draft.Status)
+	}
+	return nil
+}
@@ -2,11 +2,48 @@ package file
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
 )
 
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// Object storage, objects are addressed by the url returned from PutObject
 type FileRepository interface {
 	PutObject(ctx context.Context, input models.UploadInput) (*string, error)
-	RemoveObject(ctx context.Context, fileName string) error
+	GetObject(ctx context.Context, url string) (io.ReadCloser, error)
+	RemoveObject(ctx context.Context, url string) error
+	// List every object in storage
+	ListObjects(ctx context.Context) ([]*models.StoredObject, error)
+	// Get url for clients to upload object directly to storage
+	PresignPutObject(ctx context.Context, key string, contentType string, expires time.Duration) (string, error)
+	// Get url of object by key
+	ObjectURL(key string) string
+	// Get key of object by url, ok is false when url is not in this storage
+	ObjectKey(url string) (key string, ok bool)
+}
+
+// Records referencing stored objects by url
+type RecordRepository interface {
+	// Urls referenced by records, along with urls of uploads accounted and media left unattached after pendingSince
+	GetObjectURLs(ctx context.Context, pendingSince time.Time) ([]string, error)
+	// Remove media created before the time and never attached to a tweet, returning how many were removed
+	DeleteUnattachedMedia(ctx context.Context, before time.Time) (int64, error)
+	// Remove accounting of uploads created before the time that no record references, returning how many were removed
+	DeleteUnreferencedObjects(ctx context.Context, before time.Time) (int64, error)
+	ReplaceObjectURL(ctx context.Context, oldURL string, newURL string) (int64, error)
+}
+
+// Stored images accounted to their owners, quota of 0 is unlimited
+type ObjectRepository interface {
+	// Account image of identical content uploaded since the time to user, sql.ErrNoRows when there is none
+	Share(ctx context.Context, userID uuid.UUID, checksum string, since time.Time, quota int64) (*models.MediaObject, error)
+	Create(ctx context.Context, object *models.MediaObject, quota int64) (*models.MediaObject, error)
+	// Remove image from user, returning how many uploads still share it
+	Delete(ctx context.Context, userID uuid.UUID, url string) (int64, error)
+	GetUsage(ctx context.Context, userID uuid.UUID) (*models.StorageUsage, error)
 }
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/JamesHsu333/go-twitter/config"
 	"github.com/JamesHsu333/go-twitter/internal/file"
@@ -15,11 +19,29 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	LocalDriver = "local"
+	S3Driver    = "s3"
+)
+
+// Return file repository of storage driver in config
+func NewFileRepository(cfg *config.Config) (file.FileRepository, error) {
+	switch cfg.File.Driver {
+	case "", LocalDriver:
+		return NewLocalFileRepository(cfg), nil
+	case S3Driver:
+		return NewS3FileRepository(cfg)
+	default:
+		return nil, errors.Errorf("unknown file driver %q", cfg.File.Driver)
+	}
+}
+
+// Files on local disk served by the api
 type fileRepository struct {
 	cfg *config.Config
 }
 
-func NewFileRepository(cfg *config.Config) file.FileRepository {
+func NewLocalFileRepository(cfg *config.Config) file.FileRepository {
 	return &fileRepository{cfg: cfg}
 }
 
@@ -27,7 +49,11 @@ func (f *fileRepository) PutObject(ctx context.Context, input models.UploadInput
 	_, span := tracer.NewSpan(ctx, "fileRepository.PutObject", nil)
 	defer span.End()
 
-	filepath := path.Join(f.cfg.File.FilePath, f.generateFileName(input.Name))
+	key := input.Key
+	if key == "" {
+		key = generateFileName(input.Name)
+	}
+	filepath := path.Join(f.cfg.File.FilePath, key)
 
 	dst, err := os.Create(filepath)
 	if err != nil {
@@ -40,14 +66,42 @@ func (f *fileRepository) PutObject(ctx context.Context, input models.UploadInput
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "fileRepository.PutObject.io.Copy")
 	}
-	return &filepath, nil
+
+	url := f.ObjectURL(key)
+	return &url, nil
 }
 
-func (f *fileRepository) RemoveObject(ctx context.Context, filepath string) error {
+func (f *fileRepository) GetObject(ctx context.Context, url string) (io.ReadCloser, error) {
+	_, span := tracer.NewSpan(ctx, "fileRepository.GetObject", nil)
+	defer span.End()
+
+	key, ok := f.ObjectKey(url)
+	if !ok {
+		err := errors.Errorf("%s is not a local file", url)
+		tracer.AddSpanError(span, err)
+		return nil, errors.WithMessage(err, "fileRepository.GetObject.ObjectKey")
+	}
+
+	src, err := os.Open(path.Join(f.cfg.File.FilePath, key))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "fileRepository.GetObject.os.Open")
+	}
+	return src, nil
+}
+
+func (f *fileRepository) RemoveObject(ctx context.Context, url string) error {
 	_, span := tracer.NewSpan(ctx, "fileRepository.RemoveObject", nil)
 	defer span.End()
 
-	err := os.Remove(filepath)
+	key, ok := f.ObjectKey(url)
+	if !ok {
+		err := errors.Errorf("%s is not a local file", url)
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "fileRepository.RemoveObject.ObjectKey")
+	}
+
+	err := os.Remove(path.Join(f.cfg.File.FilePath, key))
 	if err != nil {
 		tracer.AddSpanError(span, err)
 		return errors.Wrap(err, "fileRepository.RemoveObject.os.Remove")
@@ -56,7 +110,74 @@ func (f *fileRepository) RemoveObject(ctx context.Context, filepath string) erro
 	return nil
 }
 
-func (f *fileRepository) generateFileName(fileName string) string {
+// Walk file directory, hidden files such as .gitkeep are not objects
+func (f *fileRepository) ListObjects(ctx context.Context) ([]*models.StoredObject, error) {
+	_, span := tracer.NewSpan(ctx, "fileRepository.ListObjects", nil)
+	defer span.End()
+
+	root := path.Clean(f.cfg.File.FilePath)
+	objects := make([]*models.StoredObject, 0)
+	err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() && name != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(strings.TrimPrefix(name, root+string(filepath.Separator)))
+		objects = append(objects, &models.StoredObject{
+			Key:          key,
+			URL:          f.ObjectURL(key),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "fileRepository.ListObjects.filepath.WalkDir")
+	}
+
+	return objects, nil
+}
+
+// Uploads to local disk go through the api, only s3 can presign
+func (f *fileRepository) PresignPutObject(ctx context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	return "", errors.New("presigned uploads need the s3 file driver")
+}
+
+// Files are referenced by public url, or by path when no public url is set
+func (f *fileRepository) ObjectURL(key string) string {
+	if f.cfg.File.PublicURL != "" {
+		return strings.TrimSuffix(f.cfg.File.PublicURL, "/") + "/" + key
+	}
+	return path.Join(f.cfg.File.FilePath, key)
+}
+
+func (f *fileRepository) ObjectKey(url string) (string, bool) {
+	if f.cfg.File.PublicURL != "" {
+		if key := strings.TrimPrefix(url, strings.TrimSuffix(f.cfg.File.PublicURL, "/")+"/"); key != url {
+			return key, true
+		}
+	}
+	if key := strings.TrimPrefix(url, path.Clean(f.cfg.File.FilePath)+"/"); key != url {
+		return key, true
+	}
+	return "", false
+}
+
+func generateFileName(fileName string) string {
 	uid := uuid.New().String()
 	return fmt.Sprintf("%s-%s", uid, fileName)
 }
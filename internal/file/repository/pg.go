@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Records referencing stored objects
+type recordRepo struct {
+	db *sqlx.DB
+}
+
+func NewRecordRepository(db *sqlx.DB) file.RecordRepository {
+	return &recordRepo{db: db}
+}
+
+func (r *recordRepo) GetObjectURLs(ctx context.Context, pendingSince time.Time) ([]string, error) {
+	ctx, span := tracer.NewSpan(ctx, "recordRepo.GetObjectURLs", nil)
+	defer span.End()
+
+	var urls = make([]string, 0)
+	if err := r.db.SelectContext(ctx, &urls, getObjectURLsQuery, pendingSince); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "recordRepo.GetObjectURLs.SelectContext")
+	}
+
+	return urls, nil
+}
+
+func (r *recordRepo) DeleteUnattachedMedia(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "recordRepo.DeleteUnattachedMedia", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteUnattachedMediaQuery, before)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.DeleteUnattachedMedia.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.DeleteUnattachedMedia.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *recordRepo) DeleteUnreferencedObjects(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "recordRepo.DeleteUnreferencedObjects", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteUnreferencedObjectsQuery, before)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.DeleteUnreferencedObjects.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.DeleteUnreferencedObjects.RowsAffected")
+	}
+
+	return rowsAffected, nil
+}
+
+// Point every record from old url to new url at once
+func (r *recordRepo) ReplaceObjectURL(ctx context.Context, oldURL string, newURL string) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "recordRepo.ReplaceObjectURL", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.ReplaceObjectURL.BeginTxx")
+	}
+
+	var replaced int64
+	for _, query := range replaceObjectURLQueries {
+		result, err := tx.ExecContext(ctx, query, oldURL, newURL)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return 0, errors.Wrap(err, "recordRepo.ReplaceObjectURL.ExecContext")
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return 0, errors.Wrap(err, "recordRepo.ReplaceObjectURL.RowsAffected")
+		}
+		replaced += rowsAffected
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "recordRepo.ReplaceObjectURL.Commit")
+	}
+
+	return replaced, nil
+}
+
+// Stored images accounted to their owners
+type objectRepo struct {
+	db *sqlx.DB
+}
+
+func NewObjectRepository(db *sqlx.DB) file.ObjectRepository {
+	return &objectRepo{db: db}
+}
+
+func (r *objectRepo) Share(ctx context.Context, userID uuid.UUID, checksum string, since time.Time, quota int64) (*models.MediaObject, error) {
+	ctx, span := tracer.NewSpan(ctx, "objectRepo.Share", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "objectRepo.Share.BeginTxx")
+	}
+
+	object, err := r.share(ctx, tx, userID, checksum, since, quota)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.WithMessage(err, "objectRepo.Share")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "objectRepo.Share.Commit")
+	}
+
+	return object, nil
+}
+
+func (r *objectRepo) share(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, checksum string, since time.Time, quota int64) (*models.MediaObject, error) {
+	if err := r.lockOwner(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	existing := &models.MediaObject{}
+	if err := tx.QueryRowxContext(ctx, getObjectByChecksumQuery, checksum, since).StructScan(existing); err != nil {
+		return nil, errors.Wrap(err, "StructScan")
+	}
+
+	if err := r.checkQuota(ctx, tx, userID, existing.Size, quota); err != nil {
+		return nil, err
+	}
+
+	existing.UserID = userID
+	return r.create(ctx, tx, existing)
+}
+
+func (r *objectRepo) Create(ctx context.Context, object *models.MediaObject, quota int64) (*models.MediaObject, error) {
+	ctx, span := tracer.NewSpan(ctx, "objectRepo.Create", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "objectRepo.Create.BeginTxx")
+	}
+
+	createdObject, err := r.createWithinQuota(ctx, tx, object, quota)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.WithMessage(err, "objectRepo.Create")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "objectRepo.Create.Commit")
+	}
+
+	return createdObject, nil
+}
+
+func (r *objectRepo) createWithinQuota(ctx context.Context, tx *sqlx.Tx, object *models.MediaObject, quota int64) (*models.MediaObject, error) {
+	if err := r.lockOwner(ctx, tx, object.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkQuota(ctx, tx, object.UserID, object.Size, quota); err != nil {
+		return nil, err
+	}
+
+	return r.create(ctx, tx, object)
+}
+
+// Delete upload of user and count the uploads left sharing the image in one transaction,
+// an upload sharing the image meanwhile either commits first and is counted or finds it gone
+func (r *objectRepo) Delete(ctx context.Context, userID uuid.UUID, url string) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "objectRepo.Delete", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "objectRepo.Delete.BeginTxx")
+	}
+
+	if _, err = tx.ExecContext(ctx, deleteObjectQuery, userID, url); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return 0, errors.Wrap(err, "objectRepo.Delete.ExecContext")
+	}
+
+	var remaining int64
+	if err = tx.GetContext(ctx, &remaining, countObjectsByURLQuery, url); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return 0, errors.Wrap(err, "objectRepo.Delete.GetContext")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "objectRepo.Delete.Commit")
+	}
+
+	return remaining, nil
+}
+
+func (r *objectRepo) GetUsage(ctx context.Context, userID uuid.UUID) (*models.StorageUsage, error) {
+	ctx, span := tracer.NewSpan(ctx, "objectRepo.GetUsage", nil)
+	defer span.End()
+
+	usage := &models.StorageUsage{}
+	if err := r.db.QueryRowxContext(ctx, getUsageQuery, userID).StructScan(usage); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "objectRepo.GetUsage.StructScan")
+	}
+
+	return usage, nil
+}
+
+func (r *objectRepo) lockOwner(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID) error {
+	var lockedID uuid.UUID
+	if err := tx.GetContext(ctx, &lockedID, lockObjectOwnerQuery, userID); err != nil {
+		return errors.Wrap(err, "lockOwner.GetContext")
+	}
+	return nil
+}
+
+func (r *objectRepo) checkQuota(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, size int64, quota int64) error {
+	if quota <= 0 {
+		return nil
+	}
+
+	var used int64
+	if err := tx.GetContext(ctx, &used, getUsedBytesQuery, userID); err != nil {
+		return errors.Wrap(err, "checkQuota.GetContext")
+	}
+	if used+size > quota {
+		return errors.Wrapf(file.ErrQuotaExceeded, "%d of %d bytes used, %d more", used, quota, size)
+	}
+	return nil
+}
+
+func (r *objectRepo) create(ctx context.Context, tx *sqlx.Tx, object *models.MediaObject) (*models.MediaObject, error) {
+	createdObject := &models.MediaObject{}
+	if err := tx.QueryRowxContext(
+		ctx,
+		createObjectQuery,
+		object.UserID,
+		object.URL,
+		object.Size,
+		object.ContentType,
+		object.Checksum,
+		object.Width,
+		object.Height,
+		object.BlurHash,
+	).StructScan(createdObject); err != nil {
+		return nil, errors.Wrap(err, "create.StructScan")
+	}
+	return createdObject, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/s3"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/pkg/errors"
+)
+
+// Objects in a bucket of S3-compatible storage
+type s3FileRepository struct {
+	cfg     *config.Config
+	client  *s3.Client
+	baseURL string
+}
+
+func NewS3FileRepository(cfg *config.Config) (file.FileRepository, error) {
+	client, err := s3.NewClient(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "s3.NewClient")
+	}
+
+	baseURL := strings.TrimSuffix(cfg.File.PublicURL, "/")
+	if baseURL == "" {
+		baseURL = strings.TrimSuffix(client.ObjectURL(""), "/")
+	}
+
+	return &s3FileRepository{cfg: cfg, client: client, baseURL: baseURL}, nil
+}
+
+func (f *s3FileRepository) PutObject(ctx context.Context, input models.UploadInput) (*string, error) {
+	ctx, span := tracer.NewSpan(ctx, "s3FileRepository.PutObject", nil)
+	defer span.End()
+
+	key := input.Key
+	if key == "" {
+		key = generateFileName(input.Name)
+	}
+
+	content, err := io.ReadAll(input.File)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "s3FileRepository.PutObject.io.ReadAll")
+	}
+
+	if err = f.client.PutObject(ctx, key, content, input.ContentType); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "s3FileRepository.PutObject.client.PutObject")
+	}
+
+	objectURL := f.ObjectURL(key)
+	return &objectURL, nil
+}
+
+func (f *s3FileRepository) GetObject(ctx context.Context, objectURL string) (io.ReadCloser, error) {
+	ctx, span := tracer.NewSpan(ctx, "s3FileRepository.GetObject", nil)
+	defer span.End()
+
+	key, ok := f.ObjectKey(objectURL)
+	if !ok {
+		err := errors.Errorf("%s is not in bucket %s", objectURL, f.cfg.File.S3.Bucket)
+		tracer.AddSpanError(span, err)
+		return nil, errors.WithMessage(err, "s3FileRepository.GetObject.ObjectKey")
+	}
+
+	body, err := f.client.GetObject(ctx, key)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "s3FileRepository.GetObject.client.GetObject")
+	}
+	return body, nil
+}
+
+func (f *s3FileRepository) RemoveObject(ctx context.Context, objectURL string) error {
+	ctx, span := tracer.NewSpan(ctx, "s3FileRepository.RemoveObject", nil)
+	defer span.End()
+
+	key, ok := f.ObjectKey(objectURL)
+	if !ok {
+		err := errors.Errorf("%s is not in bucket %s", objectURL, f.cfg.File.S3.Bucket)
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "s3FileRepository.RemoveObject.ObjectKey")
+	}
+
+	if err := f.client.DeleteObject(ctx, key); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "s3FileRepository.RemoveObject.client.DeleteObject")
+	}
+
+	return nil
+}
+
+func (f *s3FileRepository) ListObjects(ctx context.Context) ([]*models.StoredObject, error) {
+	ctx, span := tracer.NewSpan(ctx, "s3FileRepository.ListObjects", nil)
+	defer span.End()
+
+	objects, err := f.client.ListObjects(ctx, "")
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "s3FileRepository.ListObjects.client.ListObjects")
+	}
+
+	stored := make([]*models.StoredObject, 0, len(objects))
+	for _, o := range objects {
+		stored = append(stored, &models.StoredObject{
+			Key:          o.Key,
+			URL:          f.ObjectURL(o.Key),
+			Size:         o.Size,
+			LastModified: o.LastModified,
+		})
+	}
+
+	return stored, nil
+}
+
+func (f *s3FileRepository) PresignPutObject(ctx context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	_, span := tracer.NewSpan(ctx, "s3FileRepository.PresignPutObject", nil)
+	defer span.End()
+
+	return f.client.PresignPutObject(key, contentType, expires), nil
+}
+
+func (f *s3FileRepository) ObjectURL(key string) string {
+	return f.baseURL + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+func (f *s3FileRepository) ObjectKey(objectURL string) (string, bool) {
+	escaped := strings.TrimPrefix(objectURL, f.baseURL+"/")
+	if escaped == objectURL {
+		return "", false
+	}
+
+	key, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
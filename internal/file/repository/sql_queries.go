@@ -0,0 +1,57 @@
+package repository
+
+const (
+	recordURLsQuery = `SELECT avatar AS url FROM users WHERE avatar IS NOT NULL
+					   UNION SELECT header FROM users WHERE header IS NOT NULL
+					   UNION SELECT image FROM tweets WHERE image IS NOT NULL
+					   UNION SELECT image FROM tweet_edits WHERE image IS NOT NULL
+					   UNION SELECT image FROM messages WHERE image IS NOT NULL
+					   UNION SELECT image FROM tweet_drafts WHERE image IS NOT NULL
+					   UNION SELECT url FROM tweet_media WHERE tweet_id IS NOT NULL`
+
+	// Uploads are accounted before their record is saved, and media wait unattached for a tweet, recent ones are kept meanwhile
+	getObjectURLsQuery = recordURLsQuery + `
+						  UNION SELECT url FROM tweet_media WHERE tweet_id IS NULL AND created_at > $1
+						  UNION SELECT url FROM media_objects WHERE created_at > $1`
+
+	// Media uploaded but never attached to a tweet
+	deleteUnattachedMediaQuery = `DELETE FROM tweet_media WHERE tweet_id IS NULL AND created_at <= $1`
+
+	// Accounting of images no record references anymore, e.g. when the record update failed after upload
+	deleteUnreferencedObjectsQuery = `DELETE FROM media_objects
+									  WHERE created_at <= $1 AND url NOT IN (` + recordURLsQuery + `)`
+)
+
+// Every column holding an object url
+var replaceObjectURLQueries = []string{
+	`UPDATE users SET avatar = $2 WHERE avatar = $1`,
+	`UPDATE users SET header = $2 WHERE header = $1`,
+	`UPDATE tweets SET image = $2 WHERE image = $1`,
+	`UPDATE tweet_edits SET image = $2 WHERE image = $1`,
+	`UPDATE messages SET image = $2 WHERE image = $1`,
+	`UPDATE tweet_drafts SET image = $2 WHERE image = $1`,
+	`UPDATE tweet_media SET url = $2 WHERE url = $1`,
+	`UPDATE media_objects SET url = $2 WHERE url = $1`,
+}
+
+const (
+	// Uploads of a user are accounted one at a time, so concurrent uploads cannot exceed the quota together
+	lockObjectOwnerQuery = `SELECT user_id FROM users WHERE user_id = $1 FOR NO KEY UPDATE`
+
+	getUsedBytesQuery = `SELECT COALESCE(SUM(size), 0)::bigint FROM media_objects WHERE user_id = $1`
+
+	// Locked so that the image is not removed along with its last upload meanwhile.
+	// Only uploads since $2 are shared, older images may be taken for orphans by the collector already.
+	getObjectByChecksumQuery = `SELECT * FROM media_objects WHERE checksum = $1 AND created_at > $2 ORDER BY id LIMIT 1 FOR UPDATE`
+
+	createObjectQuery = `INSERT INTO media_objects (user_id, url, size, content_type, checksum, width, height, blurhash)
+						 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`
+
+	deleteObjectQuery = `DELETE FROM media_objects
+						 WHERE id = (SELECT id FROM media_objects WHERE user_id = $1 AND url = $2 ORDER BY id LIMIT 1)`
+
+	countObjectsByURLQuery = `SELECT COUNT(*) FROM media_objects WHERE url = $1`
+
+	getUsageQuery = `SELECT $1::uuid AS user_id, COALESCE(SUM(size), 0)::bigint AS used_bytes, COUNT(*) AS objects
+					 FROM media_objects WHERE user_id = $1`
+)
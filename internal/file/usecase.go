@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
 )
 
 // Follow usecase interface
 type UseCase interface {
-	PutObject(ctx context.Context, input models.UploadInput) (*string, error)
-	RemoveObject(ctx context.Context, fileName string) error
+	PutImage(ctx context.Context, content []byte) (*models.Image, error)
+	// Remove upload of image from its owner, who is not necessarily the current user
+	RemoveImage(ctx context.Context, ownerID uuid.UUID, url string) error
+	GetStorageUsage(ctx context.Context, userID uuid.UUID) (*models.StorageUsage, error)
 }
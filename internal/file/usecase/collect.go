@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/metric"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// Removes stored objects no record references, e.g. images replaced while their removal failed
+// or images of deleted users, whose records are removed by cascade only
+type Collector struct {
+	cfg        *config.Config
+	recordRepo file.RecordRepository
+	fileRepo   file.FileRepository
+	metrics    metric.OrphanMetrics
+	logger     logger.Logger
+}
+
+// Outcome of a collector run
+type CollectResult struct {
+	Scanned     int
+	Orphans     int
+	OrphanBytes int64
+	Removed     int
+	Failed      int
+	// Media never attached to a tweet removed, none on dry runs
+	Unattached int64
+	// Uploads no record references released from their owners' quota, none on dry runs
+	Released int64
+}
+
+func NewCollector(cfg *config.Config, recordRepo file.RecordRepository, fileRepo file.FileRepository, metrics metric.OrphanMetrics, logger logger.Logger) *Collector {
+	return &Collector{cfg: cfg, recordRepo: recordRepo, fileRepo: fileRepo, metrics: metrics, logger: logger}
+}
+
+// Collect orphans every interval until ctx is done.
+// Removing an object twice does no harm, so the collector can run on every replica.
+func (c *Collector) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(c.cfg.FileGC.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := c.Run(ctx, c.cfg.FileGC.DryRun)
+			if err != nil {
+				c.logger.Errorf("Collector.RunScheduler.Run: %v", err)
+				continue
+			}
+			c.logger.Infof("Collector: %d objects scanned, %d orphans of %d bytes, %d removed, %d failed, %d unattached media removed, %d uploads released",
+				result.Scanned, result.Orphans, result.OrphanBytes, result.Removed, result.Failed, result.Unattached, result.Released)
+		}
+	}
+}
+
+// Report objects older than the grace period that no record references, and remove them unless dryRun is set
+// along with the accounting of their uploads. Media left unattached past the grace period are removed first,
+// so their objects are collected in the same run.
+// Objects are listed before records are read, so an object referenced by a record created meanwhile is never taken for an orphan.
+func (c *Collector) Run(ctx context.Context, dryRun bool) (*CollectResult, error) {
+	ctx, span := tracer.NewSpan(ctx, "Collector.Run", nil)
+	defer span.End()
+
+	c.metrics.IncRuns(dryRun)
+
+	objects, err := c.fileRepo.ListObjects(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		c.metrics.IncRunErrors()
+		return nil, errors.WithMessage(err, "Collector.Run.ListObjects")
+	}
+
+	result := &CollectResult{Scanned: len(objects)}
+	c.metrics.AddScanned(len(objects))
+
+	cutoff := time.Now().Add(-collectGrace(c.cfg))
+	if !dryRun {
+		result.Unattached, err = c.recordRepo.DeleteUnattachedMedia(ctx, cutoff)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			c.metrics.IncRunErrors()
+			return nil, errors.WithMessage(err, "Collector.Run.DeleteUnattachedMedia")
+		}
+
+		result.Released, err = c.recordRepo.DeleteUnreferencedObjects(ctx, cutoff)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			c.metrics.IncRunErrors()
+			return nil, errors.WithMessage(err, "Collector.Run.DeleteUnreferencedObjects")
+		}
+	}
+
+	referenced, err := c.referencedKeys(ctx, cutoff)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		c.metrics.IncRunErrors()
+		return nil, err
+	}
+
+	for _, object := range objects {
+		if _, ok := referenced[object.Key]; ok || object.LastModified.After(cutoff) {
+			continue
+		}
+
+		result.Orphans++
+		result.OrphanBytes += object.Size
+		c.metrics.AddOrphans(1, object.Size)
+		c.logger.Infof("Collector: orphan %s, %d bytes, last modified %s", object.URL, object.Size, object.LastModified.Format(time.RFC3339))
+
+		if dryRun {
+			continue
+		}
+		if err = c.fileRepo.RemoveObject(ctx, object.URL); err != nil {
+			c.logger.Errorf("Collector.Run.RemoveObject: %s: %v", object.URL, err)
+			c.metrics.AddRemoveErrors(1)
+			result.Failed++
+			continue
+		}
+		c.metrics.AddRemoved(1, object.Size)
+		result.Removed++
+	}
+
+	return result, nil
+}
+
+// Keys of every object referenced by records, or uploaded or left unattached after cutoff, along with the other renditions of referenced images
+func (c *Collector) referencedKeys(ctx context.Context, cutoff time.Time) (map[string]struct{}, error) {
+	urls, err := c.recordRepo.GetObjectURLs(ctx, cutoff)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Collector.referencedKeys.GetObjectURLs")
+	}
+
+	referenced := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		objects := []string{url}
+		for _, rendition := range utils.GetImageRenditions(url) {
+			objects = append(objects, rendition)
+		}
+
+		for _, object := range objects {
+			if key, ok := c.fileRepo.ObjectKey(object); ok {
+				referenced[key] = struct{}{}
+			}
+		}
+	}
+
+	// Records pointing elsewhere, e.g. after the public url changed, would leave every object unreferenced
+	if len(urls) > 0 && len(referenced) == 0 {
+		return nil, errors.New("Collector.referencedKeys: no record references this storage, check the file PublicURL")
+	}
+
+	return referenced, nil
+}
+
+// Objects of upload sessions stay unreferenced until completed and their media unattached until tweeted,
+// they are kept at least until their session expires.
+// Uploads are only shared within the grace period, so an object taken for an orphan is never shared meanwhile.
+func collectGrace(cfg *config.Config) time.Duration {
+	grace := cfg.FileGC.GraceSeconds
+	if grace < cfg.Upload.ExpireSeconds {
+		grace = cfg.Upload.ExpireSeconds
+	}
+	return time.Duration(grace) * time.Second
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"path"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// Moves objects referenced by records from one storage to another
+type Migrator struct {
+	recordRepo file.RecordRepository
+	src        file.FileRepository
+	dst        file.FileRepository
+	logger     logger.Logger
+}
+
+func NewMigrator(recordRepo file.RecordRepository, src file.FileRepository, dst file.FileRepository, logger logger.Logger) *Migrator {
+	return &Migrator{recordRepo: recordRepo, src: src, dst: dst, logger: logger}
+}
+
+// Copy every object of source storage to destination under the same key and point records to the copy.
+// Source objects are only removed when deleteSource is set, after their records were updated.
+// Objects failing to move are logged and skipped, so the migration can be run again.
+func (m *Migrator) Run(ctx context.Context, deleteSource bool) (moved int, failed int, err error) {
+	// Uploads not saved to a record yet are moved as well
+	urls, err := m.recordRepo.GetObjectURLs(ctx, time.Time{})
+	if err != nil {
+		return 0, 0, errors.WithMessage(err, "Migrator.Run.GetObjectURLs")
+	}
+
+	for _, url := range urls {
+		if _, ok := m.src.ObjectKey(url); !ok {
+			continue
+		}
+
+		if err = m.move(ctx, url, deleteSource); err != nil {
+			m.logger.Errorf("Migrator.Run.move: %s: %v", url, err)
+			failed++
+			continue
+		}
+		moved++
+	}
+
+	return moved, failed, nil
+}
+
+func (m *Migrator) move(ctx context.Context, url string, deleteSource bool) error {
+	// Renditions of an image are moved together, records only reference the largest one
+	objects := []string{url}
+	if renditions := utils.GetImageRenditions(url); renditions != nil {
+		objects = objects[:0]
+		for _, r := range utils.ImageRenditions {
+			objects = append(objects, renditions[r.Name])
+		}
+	}
+
+	var newURL string
+	for _, object := range objects {
+		copied, err := m.copy(ctx, object)
+		if err != nil {
+			return errors.WithMessagef(err, "copy %s", object)
+		}
+		if object == url {
+			newURL = copied
+		}
+	}
+
+	replaced, err := m.recordRepo.ReplaceObjectURL(ctx, url, newURL)
+	if err != nil {
+		return errors.WithMessage(err, "ReplaceObjectURL")
+	}
+	m.logger.Infof("Migrator: %s moved to %s, %d records updated", url, newURL, replaced)
+
+	if !deleteSource {
+		return nil
+	}
+	for _, object := range objects {
+		if err = m.src.RemoveObject(ctx, object); err != nil {
+			m.logger.Errorf("Migrator.move.RemoveObject: %s: %v", object, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) copy(ctx context.Context, url string) (string, error) {
+	key, ok := m.src.ObjectKey(url)
+	if !ok {
+		return "", errors.Errorf("%s is not in source storage", url)
+	}
+
+	body, err := m.src.GetObject(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", errors.Wrap(err, "io.ReadAll")
+	}
+
+	copied, err := m.dst.PutObject(ctx, models.UploadInput{
+		File:        bytes.NewReader(content),
+		Size:        int64(len(content)),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		Key:         key,
+	})
+	if err != nil {
+		return "", err
+	}
+	return *copied, nil
+}
@@ -1,34 +1,227 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+	"time"
 
 	"github.com/JamesHsu333/go-twitter/config"
 	"github.com/JamesHsu333/go-twitter/internal/file"
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/imaging"
 	"github.com/JamesHsu333/go-twitter/pkg/logger"
 	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 type fileUC struct {
-	cfg      *config.Config
-	fileRepo file.FileRepository
-	logger   logger.Logger
+	cfg        *config.Config
+	fileRepo   file.FileRepository
+	objectRepo file.ObjectRepository
+	logger     logger.Logger
 }
 
-func NewFileUseCase(cfg *config.Config, fileRepo file.FileRepository, logger logger.Logger) file.UseCase {
-	return &fileUC{cfg: cfg, fileRepo: fileRepo, logger: logger}
+func NewFileUseCase(cfg *config.Config, fileRepo file.FileRepository, objectRepo file.ObjectRepository, logger logger.Logger) file.UseCase {
+	return &fileUC{cfg: cfg, fileRepo: fileRepo, objectRepo: objectRepo, logger: logger}
 }
 
-func (u *fileUC) PutObject(ctx context.Context, input models.UploadInput) (*string, error) {
-	ctx, span := tracer.NewSpan(ctx, "fileUC.PutObject", nil)
+// Store jpeg or png image of current user as renditions of fixed sizes, within the quota of their role.
+// Images are decoded and encoded again, dropping exif and other metadata.
+// Content stored within the collector grace period is not stored again, the image is shared and only accounted to the user.
+func (u *fileUC) PutImage(ctx context.Context, content []byte) (*models.Image, error) {
+	ctx, span := tracer.NewSpan(ctx, "fileUC.PutImage", nil)
 	defer span.End()
 
-	return u.fileRepo.PutObject(ctx, input)
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "fileUC.PutImage.GetUserFromCtx"))
+	}
+
+	quota := u.quota(self)
+	checksum := sha256.Sum256(content)
+	object := &models.MediaObject{UserID: self.UserID, Checksum: hex.EncodeToString(checksum[:])}
+
+	sharedObject, err := u.objectRepo.Share(ctx, self.UserID, object.Checksum, time.Now().Add(-collectGrace(u.cfg)), quota)
+	if err == nil {
+		return &models.Image{
+			URL:        sharedObject.URL,
+			Width:      sharedObject.Width,
+			Height:     sharedObject.Height,
+			BlurHash:   sharedObject.BlurHash,
+			Renditions: utils.GetImageRenditions(sharedObject.URL),
+		}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		tracer.AddSpanError(span, err)
+		return nil, quotaError(err, "fileUC.PutImage.Share")
+	}
+
+	// Processing is skipped when the quota is used up already, the exact size is checked once stored
+	if quota > 0 {
+		usage, err := u.objectRepo.GetUsage(ctx, self.UserID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+		if usage.UsedBytes >= quota {
+			err = errors.Wrapf(file.ErrQuotaExceeded, "%d of %d bytes used", usage.UsedBytes, quota)
+			tracer.AddSpanError(span, err)
+			return nil, quotaError(err, "fileUC.PutImage.GetUsage")
+		}
+	}
+
+	img, format, err := imaging.Decode(content)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "fileUC.PutImage.Decode"))
+	}
+
+	extension, contentType := "jpg", "image/jpeg"
+	if format == "png" {
+		extension, contentType = "png", "image/png"
+	}
+
+	prefix := uuid.New().String()
+	image := &models.Image{Renditions: make(map[string]string, len(utils.ImageRenditions))}
+
+	// Every rendition is scaled down from the previous larger one
+	for _, r := range utils.ImageRenditions {
+		img = imaging.Fit(img, r.MaxSize)
+
+		encoded, err := imaging.Encode(img, format)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			u.removeRenditions(ctx, image.Renditions)
+			return nil, errors.Wrap(err, "fileUC.PutImage.Encode")
+		}
+
+		url, err := u.fileRepo.PutObject(ctx, models.UploadInput{
+			File:        bytes.NewReader(encoded),
+			Size:        int64(len(encoded)),
+			ContentType: contentType,
+			Key:         utils.GetImageRenditionKey(prefix, r.Name, extension),
+		})
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			u.removeRenditions(ctx, image.Renditions)
+			return nil, err
+		}
+		image.Renditions[r.Name] = *url
+		object.Size += int64(len(encoded))
+
+		if image.URL == "" {
+			image.URL = *url
+			image.Width, image.Height = img.Bounds().Dx(), img.Bounds().Dy()
+		}
+	}
+
+	image.BlurHash = imaging.BlurHash(img)
+
+	object.URL = image.URL
+	object.ContentType = contentType
+	object.Width, object.Height = image.Width, image.Height
+	object.BlurHash = image.BlurHash
+	if _, err = u.objectRepo.Create(ctx, object, quota); err != nil {
+		tracer.AddSpanError(span, err)
+		u.removeRenditions(ctx, image.Renditions)
+		return nil, quotaError(err, "fileUC.PutImage.Create")
+	}
+
+	return image, nil
+}
+
+// Remove image from the owner recorded on its record, e.g. the author of a tweet an admin deletes,
+// renditions are removed from storage once no other upload shares them
+func (u *fileUC) RemoveImage(ctx context.Context, ownerID uuid.UUID, url string) error {
+	ctx, span := tracer.NewSpan(ctx, "fileUC.RemoveImage", nil)
+	defer span.End()
+
+	remaining, err := u.objectRepo.Delete(ctx, ownerID, url)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	renditions := utils.GetImageRenditions(url)
+	if renditions == nil {
+		return u.fileRepo.RemoveObject(ctx, url)
+	}
+
+	for _, r := range renditions {
+		if rmErr := u.fileRepo.RemoveObject(ctx, r); rmErr != nil {
+			tracer.AddSpanError(span, rmErr)
+			err = rmErr
+		}
+	}
+	return err
 }
-func (u *fileUC) RemoveObject(ctx context.Context, fileName string) error {
-	ctx, span := tracer.NewSpan(ctx, "fileUC.RemoveObject", nil)
+
+func (u *fileUC) GetStorageUsage(ctx context.Context, userID uuid.UUID) (*models.StorageUsage, error) {
+	ctx, span := tracer.NewSpan(ctx, "fileUC.GetStorageUsage", nil)
 	defer span.End()
 
-	return u.fileRepo.RemoveObject(ctx, fileName)
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "fileUC.GetStorageUsage.GetUserFromCtx"))
+	}
+
+	if self.UserID != userID {
+		err = errors.New("storage usage of other user")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewForbiddenError(errors.WithMessage(err, "fileUC.GetStorageUsage"))
+	}
+
+	usage, err := u.objectRepo.GetUsage(ctx, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if quota := u.quota(self); quota > 0 {
+		remaining := quota - usage.UsedBytes
+		if remaining < 0 {
+			remaining = 0
+		}
+		usage.QuotaBytes = &quota
+		usage.RemainingBytes = &remaining
+	}
+
+	return usage, nil
+}
+
+// Quota of user by role, 0 is unlimited
+func (u *fileUC) quota(user *models.User) int64 {
+	if user.Role != nil {
+		if quota, ok := u.cfg.Quota.RoleBytes[*user.Role]; ok {
+			return quota
+		}
+	}
+	return u.cfg.Quota.DefaultBytes
+}
+
+func (u *fileUC) removeRenditions(ctx context.Context, renditions map[string]string) {
+	for _, r := range renditions {
+		if err := u.fileRepo.RemoveObject(ctx, r); err != nil {
+			u.logger.Errorf("fileUC.removeRenditions.RemoveObject: %v", err)
+		}
+	}
+}
+
+func quotaError(err error, message string) error {
+	if errors.Is(err, file.ErrQuotaExceeded) {
+		return httpErrors.NewRestErrorWithMessage(http.StatusForbidden, httpErrors.ErrStorageQuotaExceeded, errors.WithMessage(err, message))
+	}
+	return err
 }
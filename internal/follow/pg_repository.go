@@ -12,5 +12,14 @@ type Repository interface {
 	Follow(ctx context.Context, follower uuid.UUID, following uuid.UUID) error
 	GetFollowers(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
 	GetFollowing(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetFollowersCount(ctx context.Context, userID uuid.UUID) (int64, error)
+	IsFollowing(ctx context.Context, follower uuid.UUID, following uuid.UUID) (bool, error)
+	IsPrivate(ctx context.Context, userID uuid.UUID) (bool, error)
+	CanViewTweets(ctx context.Context, viewerID uuid.UUID, userID uuid.UUID) (bool, error)
+	CreateFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error
+	GetFollowRequests(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	ApproveFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error
+	DeleteFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error
 	Delete(ctx context.Context, follower uuid.UUID, following uuid.UUID) error
 }
@@ -50,28 +50,34 @@ func (r *followRepo) GetFollowers(ctx context.Context, selfID uuid.UUID, userID
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getFollowersTotal, userID.String()); err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "followRepo.GetFollowers.GetContext.getTotal")
-	}
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getFollowersTotal, userID.String(), selfID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "followRepo.GetFollowers.GetContext.getTotal")
+		}
 
-	if totalCount == 0 {
-		return &models.UsersList{
-			TotalCount: totalCount,
-			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
-			Page:       pq.GetPage(),
-			Size:       pq.GetSize(),
-			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
-			Users:      make([]*models.User, 0),
-		}, nil
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
 	}
 
-	var users = make([]*models.User, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &users, getFollowers, selfID.String(), userID.String(), pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getFollowers, selfID.String(), userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "followRepo.GetFollowers.SelectContext")
 	}
 
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
 	return &models.UsersList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
@@ -87,26 +93,165 @@ func (r *followRepo) GetFollowing(ctx context.Context, selfID uuid.UUID, userID
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getFollowingTotal, userID.String()); err != nil {
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getFollowingTotal, userID.String(), selfID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "followRepo.GetFollowing.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getFollowing, selfID.String(), userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "followRepo.GetFollowing.GetContext.getTotal")
+		return nil, errors.Wrap(err, "followRepo.GetFollowing.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
 	}
 
-	if totalCount == 0 {
-		return &models.UsersList{
-			TotalCount: totalCount,
-			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
-			Page:       pq.GetPage(),
-			Size:       pq.GetSize(),
-			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
-			Users:      make([]*models.User, 0),
-		}, nil
+	return &models.UsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+func (r *followRepo) GetFollowerIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.GetFollowerIDs", nil)
+	defer span.End()
+
+	var followerIDs []uuid.UUID
+	if err := r.db.SelectContext(ctx, &followerIDs, getFollowerIDs, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "followRepo.GetFollowerIDs.SelectContext")
 	}
 
-	var users = make([]*models.User, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &users, getFollowing, selfID.String(), userID.String(), pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	return followerIDs, nil
+}
+
+func (r *followRepo) GetFollowersCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.GetFollowersCount", nil)
+	defer span.End()
+
+	var totalCount int64
+	if err := r.db.GetContext(ctx, &totalCount, getFollowersCount, userID.String()); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "followRepo.GetFollowing.SelectContext")
+		return 0, errors.Wrap(err, "followRepo.GetFollowersCount.GetContext")
+	}
+
+	return totalCount, nil
+}
+
+func (r *followRepo) IsFollowing(ctx context.Context, follower uuid.UUID, following uuid.UUID) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.IsFollowing", nil)
+	defer span.End()
+
+	var isFollowing bool
+	if err := r.db.GetContext(ctx, &isFollowing, checkFollowing, follower.String(), following.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "followRepo.IsFollowing.GetContext")
+	}
+
+	return isFollowing, nil
+}
+
+// Check if user account is private
+func (r *followRepo) IsPrivate(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.IsPrivate", nil)
+	defer span.End()
+
+	var isPrivate bool
+	if err := r.db.GetContext(ctx, &isPrivate, checkPrivate, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "followRepo.IsPrivate.GetContext")
+	}
+
+	return isPrivate, nil
+}
+
+// Check if viewer can see tweets of user, private accounts are only visible to themselves and their followers
+func (r *followRepo) CanViewTweets(ctx context.Context, viewerID uuid.UUID, userID uuid.UUID) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.CanViewTweets", nil)
+	defer span.End()
+
+	var canView bool
+	if err := r.db.GetContext(ctx, &canView, checkCanViewTweets, viewerID.String(), userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "followRepo.CanViewTweets.GetContext")
+	}
+
+	return canView, nil
+}
+
+func (r *followRepo) CreateFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.CreateFollowRequest", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, createFollowRequestQuery, requester, target)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "followRepo.CreateFollowRequest.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "followRepo.CreateFollowRequest.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "followRepo.CreateFollowRequest.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get users waiting for approval to follow user
+func (r *followRepo) GetFollowRequests(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.GetFollowRequests", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getFollowRequestsTotal, userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "followRepo.GetFollowRequests.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getFollowRequests, userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "followRepo.GetFollowRequests.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
 	}
 
 	return &models.UsersList{
@@ -119,6 +264,51 @@ func (r *followRepo) GetFollowing(ctx context.Context, selfID uuid.UUID, userID
 	}, nil
 }
 
+// Turn pending follow request into follow
+func (r *followRepo) ApproveFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.ApproveFollowRequest", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, approveFollowRequestQuery, requester, target)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "followRepo.ApproveFollowRequest.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "followRepo.ApproveFollowRequest.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "followRepo.ApproveFollowRequest.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *followRepo) DeleteFollowRequest(ctx context.Context, requester uuid.UUID, target uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "followRepo.DeleteFollowRequest", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteFollowRequestQuery, requester, target)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "followRepo.DeleteFollowRequest.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "followRepo.DeleteFollowRequest.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "followRepo.DeleteFollowRequest.rowsAffected")
+	}
+
+	return nil
+}
+
 func (r *followRepo) Delete(ctx context.Context, follower uuid.UUID, following uuid.UUID) error {
 	ctx, span := tracer.NewSpan(ctx, "followRepo.Delete", nil)
 	defer span.End()
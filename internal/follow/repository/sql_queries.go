@@ -4,9 +4,12 @@ const (
 	followQuery = `INSERT INTO follows (follower_id, following_id, created_at)
 				   VALUES ($1, $2, now())`
 
+	checkFollowing = `SELECT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND following_id = $2)`
+
 	getFollowersTotal = `SELECT COUNT(f.follower_id)
 						 FROM follows f
-						 WHERE f.following_id = $1`
+						 WHERE f.following_id = $1
+						 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $2 AND bl.blocked_id = f.follower_id) OR (bl.blocker_id = f.follower_id AND bl.blocked_id = $2))`
 
 	getFollowers = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
 					u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
@@ -14,12 +17,15 @@ const (
 					FROM users u
 					INNER JOIN follows f ON f.follower_id = u.user_id
 					WHERE f.following_id = $2
-					ORDER BY COALESCE(NULLIF($3, ''), u.name) OFFSET $4 LIMIT $5
+					AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					AND ($3::text IS NULL OR (u.name, u.user_id) > ($3::text, $4::text::uuid))
+					ORDER BY u.name, u.user_id OFFSET $5 LIMIT $6
 					`
 
 	getFollowingTotal = `SELECT COUNT(f.following_id)
 						 FROM follows f
-						 WHERE f.follower_id = $1`
+						 WHERE f.follower_id = $1
+						 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $2 AND bl.blocked_id = f.following_id) OR (bl.blocker_id = f.following_id AND bl.blocked_id = $2))`
 
 	getFollowing = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
 					u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
@@ -27,8 +33,50 @@ const (
 					FROM users u
 					INNER JOIN follows f ON f.following_id = u.user_id
 					WHERE f.follower_id = $2
-					ORDER BY COALESCE(NULLIF($3, ''), u.name) OFFSET $4 LIMIT $5
+					AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					AND ($3::text IS NULL OR (u.name, u.user_id) > ($3::text, $4::text::uuid))
+					ORDER BY u.name, u.user_id OFFSET $5 LIMIT $6
 					`
 
+	getFollowersCount = `SELECT COUNT(f.follower_id) FROM follows f WHERE f.following_id = $1`
+
+	getFollowerIDs = `SELECT f.follower_id FROM follows f WHERE f.following_id = $1`
+
+	checkPrivate = `SELECT u.is_private FROM users u WHERE u.user_id = $1`
+
+	checkCanViewTweets = `SELECT (NOT u.is_private OR u.user_id = $1
+						  OR EXISTS (SELECT 1 FROM follows f WHERE f.follower_id = $1 AND f.following_id = u.user_id))
+						  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						  FROM users u
+						  WHERE u.user_id = $2`
+
+	createFollowRequestQuery = `INSERT INTO follow_requests (requester_id, target_id, created_at)
+								VALUES ($1, $2, now())`
+
+	getFollowRequestsTotal = `SELECT COUNT(fr.requester_id)
+							  FROM follow_requests fr
+							  WHERE fr.target_id = $1`
+
+	getFollowRequests = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
+						 u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+						 EXISTS (SELECT 1 FROM follows f where u.user_id = f.following_id and f.follower_id = $1) AS is_following
+						 FROM users u
+						 INNER JOIN follow_requests fr ON fr.requester_id = u.user_id
+						 WHERE fr.target_id = $1
+						 AND ($2::text IS NULL OR (u.name, u.user_id) > ($2::text, $3::text::uuid))
+						 ORDER BY u.name, u.user_id OFFSET $4 LIMIT $5
+						`
+
+	approveFollowRequestQuery = `WITH __r AS
+									(DELETE FROM follow_requests
+									WHERE requester_id = $1 AND target_id = $2
+									RETURNING requester_id, target_id
+									)
+								 INSERT INTO follows (follower_id, following_id, created_at)
+								 SELECT requester_id, target_id, now() FROM __r
+								 ON CONFLICT DO NOTHING`
+
+	deleteFollowRequestQuery = `DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2`
+
 	deleteQuery = `DELETE FROM follows f WHERE f.follower_id = $1 AND f.following_id = $2`
 )
@@ -10,8 +10,11 @@ import (
 
 // Follow usecase interface
 type UseCase interface {
-	Follow(ctx context.Context, follower uuid.UUID, following uuid.UUID) error
+	Follow(ctx context.Context, follower uuid.UUID, following uuid.UUID) (bool, error)
 	GetFollowers(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
 	GetFollowing(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	GetFollowRequests(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error)
+	ApproveFollowRequest(ctx context.Context, userID uuid.UUID, requester uuid.UUID) error
+	DenyFollowRequest(ctx context.Context, userID uuid.UUID, requester uuid.UUID) error
 	Delete(ctx context.Context, follower uuid.UUID, following uuid.UUID) error
 }
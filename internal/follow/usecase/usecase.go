@@ -2,15 +2,22 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/block"
 	"github.com/JamesHsu333/go-twitter/internal/follow"
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
 	"github.com/JamesHsu333/go-twitter/pkg/logger"
 	"github.com/JamesHsu333/go-twitter/pkg/tracer"
 	"github.com/JamesHsu333/go-twitter/pkg/utils"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -22,37 +29,127 @@ type followUC struct {
 	cfg             *config.Config
 	followRepo      follow.Repository
 	followRedisRepo follow.RedisRepository
+	blockRepo       block.Repository
+	tweetRepo       tweet.Repository
+	tweetRedisRepo  tweet.RedisRepository
+	notificationUC  notification.UseCase
+	streamUC        stream.UseCase
 	logger          logger.Logger
 }
 
 // New Usecase
-func NewFollowUseCase(cfg *config.Config, followRepo follow.Repository, followRedisRepo follow.RedisRepository, logger logger.Logger) follow.UseCase {
-	return &followUC{cfg: cfg, followRepo: followRepo, followRedisRepo: followRedisRepo, logger: logger}
+func NewFollowUseCase(cfg *config.Config, followRepo follow.Repository, followRedisRepo follow.RedisRepository,
+	blockRepo block.Repository, tweetRepo tweet.Repository, tweetRedisRepo tweet.RedisRepository, notificationUC notification.UseCase, streamUC stream.UseCase, logger logger.Logger) follow.UseCase {
+	return &followUC{
+		cfg:             cfg,
+		followRepo:      followRepo,
+		followRedisRepo: followRedisRepo,
+		blockRepo:       blockRepo,
+		tweetRepo:       tweetRepo,
+		tweetRedisRepo:  tweetRedisRepo,
+		notificationUC:  notificationUC,
+		streamUC:        streamUC,
+		logger:          logger,
+	}
 }
 
-func (u *followUC) Follow(ctx context.Context, follower uuid.UUID, following uuid.UUID) error {
+// Follow user, returns true when the user is private and a follow request is pending instead
+func (u *followUC) Follow(ctx context.Context, follower uuid.UUID, following uuid.UUID) (bool, error) {
 	ctx, span := tracer.NewSpan(ctx, "followUC.Follow", nil)
 	defer span.End()
 
-	if err := u.followRepo.Follow(ctx, follower, following); err != nil {
+	isBlocked, err := u.blockRepo.IsBlocked(ctx, follower, following)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return false, err
+	}
+	if isBlocked {
+		err = errors.Errorf("follow between %s and %s is blocked", follower, following)
+		tracer.AddSpanError(span, err)
+		return false, httpErrors.NewForbiddenError(errors.WithMessage(err, "followUC.Follow.IsBlocked"))
+	}
+
+	isPrivate, err := u.followRepo.IsPrivate(ctx, following)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return false, err
+	}
+
+	if isPrivate {
+		isFollowing, err := u.followRepo.IsFollowing(ctx, follower, following)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return false, err
+		}
+		if !isFollowing {
+			if err = u.followRepo.CreateFollowRequest(ctx, follower, following); err != nil {
+				tracer.AddSpanError(span, err)
+				u.logger.Errorf("followUC.Follow.CreateFollowRequest: %v", err)
+				return false, err
+			}
+
+			if err = u.notificationUC.Notify(ctx, &models.Notification{UserID: following, ActorID: follower, Type: models.NotificationFollowRequest}); err != nil {
+				tracer.AddSpanError(span, err)
+				u.logger.Errorf("followUC.Follow.Notify: %v", err)
+			}
+
+			return true, nil
+		}
+	}
+
+	if err = u.followRepo.Follow(ctx, follower, following); err != nil {
 		tracer.AddSpanError(span, err)
 		u.logger.Errorf("followUC.Follow.Follow: %v", err)
-		return err
+		return false, err
 	}
 
-	if err := u.followRedisRepo.DeleteFollowCtx(ctx, u.generateFollowKey("following of", follower.String())); err != nil {
+	u.afterFollow(ctx, follower, following)
+
+	if err = u.notificationUC.Notify(ctx, &models.Notification{UserID: following, ActorID: follower, Type: models.NotificationFollow}); err != nil {
 		tracer.AddSpanError(span, err)
-		u.logger.Infof("followUC.Follow.DeleteFollowCtx: %v", err)
+		u.logger.Errorf("followUC.Follow.Notify: %v", err)
 	}
 
-	if err := u.followRedisRepo.DeleteFollowCtx(ctx, u.generateFollowKey("followers of", following.String())); err != nil {
+	return false, nil
+}
+
+// Get users waiting for approval to follow user
+func (u *followUC) GetFollowRequests(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "followUC.GetFollowRequests", nil)
+	defer span.End()
+
+	return u.followRepo.GetFollowRequests(ctx, userID, pq)
+}
+
+// Approve follow request of requester to user
+func (u *followUC) ApproveFollowRequest(ctx context.Context, userID uuid.UUID, requester uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "followUC.ApproveFollowRequest", nil)
+	defer span.End()
+
+	if err := u.followRepo.ApproveFollowRequest(ctx, requester, userID); err != nil {
 		tracer.AddSpanError(span, err)
-		u.logger.Infof("followUC.Follow.DeleteFollowCtx: %v", err)
+		u.logger.Errorf("followUC.ApproveFollowRequest.ApproveFollowRequest: %v", err)
+		return err
+	}
+
+	u.afterFollow(ctx, requester, userID)
+
+	if err := u.notificationUC.Notify(ctx, &models.Notification{UserID: requester, ActorID: userID, Type: models.NotificationFollowAccept}); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.ApproveFollowRequest.Notify: %v", err)
 	}
 
 	return nil
 }
 
+// Deny follow request of requester to user
+func (u *followUC) DenyFollowRequest(ctx context.Context, userID uuid.UUID, requester uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "followUC.DenyFollowRequest", nil)
+	defer span.End()
+
+	return u.followRepo.DeleteFollowRequest(ctx, requester, userID)
+}
+
 func (u *followUC) GetFollowers(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.UsersList, error) {
 	ctx, span := tracer.NewSpan(ctx, "followUC.GetFollowers", nil)
 	defer span.End()
@@ -72,6 +169,12 @@ func (u *followUC) Delete(ctx context.Context, follower uuid.UUID, following uui
 	defer span.End()
 
 	if err := u.followRepo.Delete(ctx, follower, following); err != nil {
+		// Unfollowing a private user before approval cancels the pending request
+		if errors.Is(err, sql.ErrNoRows) {
+			if err = u.followRepo.DeleteFollowRequest(ctx, follower, following); err == nil {
+				return nil
+			}
+		}
 		tracer.AddSpanError(span, err)
 		u.logger.Infof("followUC.Delete.Delete: %v", err)
 		return err
@@ -87,9 +190,86 @@ func (u *followUC) Delete(ctx context.Context, follower uuid.UUID, following uui
 		u.logger.Infof("followUC.Delete.DeleteFollowCtx: %v", err)
 	}
 
+	u.trimTimeline(ctx, follower, following)
+
 	return nil
 }
 
+// Refresh follow caches and timeline, and tell the followed user
+func (u *followUC) afterFollow(ctx context.Context, follower uuid.UUID, following uuid.UUID) {
+	ctx, span := tracer.NewSpan(ctx, "followUC.afterFollow", nil)
+	defer span.End()
+
+	if err := u.followRedisRepo.DeleteFollowCtx(ctx, u.generateFollowKey("following of", follower.String())); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Infof("followUC.afterFollow.DeleteFollowCtx: %v", err)
+	}
+
+	if err := u.followRedisRepo.DeleteFollowCtx(ctx, u.generateFollowKey("followers of", following.String())); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Infof("followUC.afterFollow.DeleteFollowCtx: %v", err)
+	}
+
+	u.backfillTimeline(ctx, follower, following)
+
+	if err := u.streamUC.Publish(ctx, models.EventFollow, &models.FollowEvent{FollowerID: follower}, following); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.afterFollow.Publish: %v", err)
+	}
+}
+
+// Copy recent tweets of a newly followed user into the follower's home timeline
+func (u *followUC) backfillTimeline(ctx context.Context, follower uuid.UUID, following uuid.UUID) {
+	ctx, span := tracer.NewSpan(ctx, "followUC.backfillTimeline", nil)
+	defer span.End()
+
+	followersCount, err := u.followRepo.GetFollowersCount(ctx, following)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.backfillTimeline.GetFollowersCount: %v", err)
+		return
+	}
+
+	// Popular accounts are merged on read
+	if followersCount >= u.cfg.Timeline.FanoutThreshold {
+		return
+	}
+
+	tweetIDs, err := u.tweetRepo.GetTweetIDsByUserID(ctx, following, u.cfg.Timeline.BackfillSize)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.backfillTimeline.GetTweetIDsByUserID: %v", err)
+		return
+	}
+
+	if err = u.tweetRedisRepo.AddTimelineCtx(ctx, u.generateTimelineKey(follower.String()), u.cfg.Timeline.MaxSize, tweetIDs...); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.backfillTimeline.AddTimelineCtx: %v", err)
+	}
+}
+
+// Remove tweets of an unfollowed user from the follower's home timeline
+func (u *followUC) trimTimeline(ctx context.Context, follower uuid.UUID, following uuid.UUID) {
+	ctx, span := tracer.NewSpan(ctx, "followUC.trimTimeline", nil)
+	defer span.End()
+
+	tweetIDs, err := u.tweetRepo.GetTweetIDsByUserID(ctx, following, int(u.cfg.Timeline.MaxSize))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.trimTimeline.GetTweetIDsByUserID: %v", err)
+		return
+	}
+
+	if err = u.tweetRedisRepo.RemoveTimelineCtx(ctx, u.generateTimelineKey(follower.String()), tweetIDs...); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("followUC.trimTimeline.RemoveTimelineCtx: %v", err)
+	}
+}
+
 func (u *followUC) generateFollowKey(follow string, user string) string {
 	return fmt.Sprintf("%s: %s %s", basePrefix, follow, user)
 }
+
+func (u *followUC) generateTimelineKey(user string) string {
+	return fmt.Sprintf("%s: %s %s", basePrefix, "timeline of", user)
+}
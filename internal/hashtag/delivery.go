@@ -0,0 +1,9 @@
+package hashtag
+
+import "github.com/labstack/echo/v4"
+
+// Hashtag HTTP Handlers interface
+type Handlers interface {
+	GetTweetsByHashtag() echo.HandlerFunc
+	GetTrends() echo.HandlerFunc
+}
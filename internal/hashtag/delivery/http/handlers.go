@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Hashtag handlers
+type HashtagHandlers struct {
+	cfg       *config.Config
+	hashtagUC hashtag.UseCase
+	logger    logger.Logger
+}
+
+// NewHashtagHandlers Hashtag handlers constructor
+func NewHashtagHandlers(cfg *config.Config, hashtagUC hashtag.UseCase, logger logger.Logger) hashtag.Handlers {
+	return &HashtagHandlers{cfg: cfg, hashtagUC: hashtagUC, logger: logger}
+}
+
+// GetTweetsByHashtag godoc
+// @Summary Get tweets by hashtag
+// @Description Get the list of tweets containing a hashtag, newest first
+// @Tags Hashtag
+// @Accept json
+// @Param tag path string true "hashtag"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /hashtags/{tag}/tweets [get]
+func (h *HashtagHandlers) GetTweetsByHashtag() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "HashtagHandlers.GetTweetsByHashtag", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetsList, err := h.hashtagUC.GetTweetsByHashtag(ctx, c.Param("tag"), paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweetsList)
+	}
+}
+
+// GetTrends godoc
+// @Summary Get trending hashtags
+// @Description Get hashtags whose recent usage is rising fastest
+// @Tags Hashtag
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.TrendsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /trends [get]
+func (h *HashtagHandlers) GetTrends() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "HashtagHandlers.GetTrends", nil)
+		defer span.End()
+
+		trendsList, err := h.hashtagUC.GetTrends(ctx)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, trendsList)
+	}
+}
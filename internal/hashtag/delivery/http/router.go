@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map hashtag routes
+func MapHashtagRoutes(hashtagGroup *echo.Group, h hashtag.Handlers, mw *middleware.MiddlewareManager) {
+	hashtagGroup.Use(mw.AuthSessionMiddleware)
+	hashtagGroup.GET("/:tag/tweets", h.GetTweetsByHashtag())
+}
+
+// Map trend routes
+func MapTrendRoutes(trendGroup *echo.Group, h hashtag.Handlers, mw *middleware.MiddlewareManager) {
+	trendGroup.Use(mw.AuthSessionMiddleware)
+	trendGroup.GET("", h.GetTrends())
+}
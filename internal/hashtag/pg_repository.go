@@ -0,0 +1,15 @@
+package hashtag
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Hashtag repository interface
+type Repository interface {
+	CreateTweetHashtags(ctx context.Context, tweetID uint64, tags []string) error
+	GetTweetsByHashtag(ctx context.Context, selfID uuid.UUID, tag string, pq *utils.PaginationQuery) (*models.TweetsList, error)
+}
@@ -0,0 +1,15 @@
+package hashtag
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+)
+
+// Hashtag Redis repository interface
+type RedisRepository interface {
+	IncrTrendsCtx(ctx context.Context, key string, seconds int, tags []string) error
+	UnionTrendsCtx(ctx context.Context, dest string, seconds int, keys []string) error
+	GetTopTrendsCtx(ctx context.Context, key string, limit int) ([]*models.Trend, error)
+	GetTrendCountsCtx(ctx context.Context, key string, tags []string) ([]int64, error)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Hashtag repository
+type hashtagRepo struct {
+	db *sqlx.DB
+}
+
+func NewHashtagRepository(db *sqlx.DB) hashtag.Repository {
+	return &hashtagRepo{db: db}
+}
+
+// Link tweet to hashtags, creating missing hashtags
+func (r *hashtagRepo) CreateTweetHashtags(ctx context.Context, tweetID uint64, tags []string) error {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRepo.CreateTweetHashtags", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "hashtagRepo.CreateTweetHashtags.BeginTxx")
+	}
+
+	for _, tag := range tags {
+		if _, err = tx.ExecContext(ctx, createTweetHashtagQuery, tweetID, tag); err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return errors.Wrap(err, "hashtagRepo.CreateTweetHashtags.ExecContext")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "hashtagRepo.CreateTweetHashtags.Commit")
+	}
+
+	return nil
+}
+
+func (r *hashtagRepo) GetTweetsByHashtag(ctx context.Context, selfID uuid.UUID, tag string, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRepo.GetTweetsByHashtag", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getHashtagTweetsTotal, tag, selfID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "hashtagRepo.GetTweetsByHashtag.GetContext.getHashtagTweetsTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.TweetsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Tweets:     make([]*models.TweetWithUser, 0),
+		}, nil
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &tweets, getTweetsByHashtag, selfID.String(), tag, pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "hashtagRepo.GetTweetsByHashtag.SelectContext")
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Hashtag redis repository
+type hashtagRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Hashtag redis repository constructor
+func NewHashtagRedisRepo(redisClient *redis.Client) hashtag.RedisRepository {
+	return &hashtagRedisRepo{redisClient: redisClient}
+}
+
+// Count hashtag usage in a time bucket
+func (a *hashtagRedisRepo) IncrTrendsCtx(ctx context.Context, key string, seconds int, tags []string) error {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRedisRepo.IncrTrendsCtx", nil)
+	defer span.End()
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pipe := a.redisClient.Pipeline()
+	for _, tag := range tags {
+		pipe.ZIncrBy(ctx, key, 1, tag)
+	}
+	pipe.Expire(ctx, key, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "hashtagRedisRepo.IncrTrendsCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Sum time buckets into dest
+func (a *hashtagRedisRepo) UnionTrendsCtx(ctx context.Context, dest string, seconds int, keys []string) error {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRedisRepo.UnionTrendsCtx", nil)
+	defer span.End()
+
+	pipe := a.redisClient.TxPipeline()
+	pipe.ZUnionStore(ctx, dest, &redis.ZStore{Keys: keys, Aggregate: "SUM"})
+	pipe.Expire(ctx, dest, time.Second*time.Duration(seconds))
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "hashtagRedisRepo.UnionTrendsCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Get most used hashtags of a key
+func (a *hashtagRedisRepo) GetTopTrendsCtx(ctx context.Context, key string, limit int) ([]*models.Trend, error) {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRedisRepo.GetTopTrendsCtx", nil)
+	defer span.End()
+
+	members, err := a.redisClient.ZRevRangeWithScores(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "hashtagRedisRepo.GetTopTrendsCtx.redisClient.ZRevRangeWithScores")
+	}
+
+	trends := make([]*models.Trend, 0, len(members))
+	for _, m := range members {
+		tag, ok := m.Member.(string)
+		if !ok {
+			continue
+		}
+		trends = append(trends, &models.Trend{Hashtag: tag, TweetCount: int64(m.Score)})
+	}
+	return trends, nil
+}
+
+// Get usage counts of hashtags in a key, missing hashtags count as zero
+func (a *hashtagRedisRepo) GetTrendCountsCtx(ctx context.Context, key string, tags []string) ([]int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "hashtagRedisRepo.GetTrendCountsCtx", nil)
+	defer span.End()
+
+	pipe := a.redisClient.Pipeline()
+	cmds := make([]*redis.FloatCmd, 0, len(tags))
+	for _, tag := range tags {
+		cmds = append(cmds, pipe.ZScore(ctx, key, tag))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "hashtagRedisRepo.GetTrendCountsCtx.pipe.Exec")
+	}
+
+	counts := make([]int64, 0, len(tags))
+	for _, cmd := range cmds {
+		score, err := cmd.Result()
+		if err != nil && err != redis.Nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "hashtagRedisRepo.GetTrendCountsCtx.cmd.Result")
+		}
+		counts = append(counts, int64(score))
+	}
+	return counts, nil
+}
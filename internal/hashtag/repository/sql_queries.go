@@ -0,0 +1,47 @@
+package repository
+
+const (
+	createTweetHashtagQuery = `WITH __h AS
+								(INSERT INTO hashtags (tag, created_at)
+								VALUES ($2, now())
+								ON CONFLICT (tag) DO UPDATE SET tag = EXCLUDED.tag
+								RETURNING id
+								)
+							   INSERT INTO tweet_hashtags (tweet_id, hashtag_id)
+							   SELECT $1, __h.id FROM __h
+							   ON CONFLICT DO NOTHING`
+
+	getHashtagTweetsTotal = `SELECT COUNT(th.tweet_id)
+							 FROM tweet_hashtags th
+							 INNER JOIN hashtags h ON h.id = th.hashtag_id
+							 INNER JOIN tweets t ON t.id = th.tweet_id
+							 INNER JOIN users u ON t.user_id = u.user_id
+							 WHERE h.tag = $1
+							 AND (NOT u.is_private OR u.user_id = $2 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $2 AND vf.following_id = u.user_id))
+							 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $2 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $2))
+							 AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $2 AND mu.muted_id = u.user_id)`
+
+	getTweetsByHashtag = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						  u.user_id, u.name, u.user_name, u.about, u.avatar,
+						  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						  EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						  EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						  (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						  (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						  COALESCE(t.conversation_id, t.id) AS conversation_id
+						  FROM tweets t
+						  INNER JOIN users u ON t.user_id = u.user_id
+						  LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+						  LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						  LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						  WHERE t.id IN (SELECT th.tweet_id FROM tweet_hashtags th
+						  				 INNER JOIN hashtags h ON h.id = th.hashtag_id WHERE h.tag = $2)
+						  AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+						  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						  u.user_id, u.name, u.user_name, u.about, u.avatar
+						  ORDER BY t.id desc
+						  OFFSET $3 LIMIT $4`
+)
@@ -0,0 +1,15 @@
+package hashtag
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Hashtag usecase interface
+type UseCase interface {
+	AddTweetHashtags(ctx context.Context, tweet *models.Tweet) error
+	GetTweetsByHashtag(ctx context.Context, tag string, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetTrends(ctx context.Context) (*models.TrendsList, error)
+}
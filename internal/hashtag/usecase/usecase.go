@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	basePrefix          = "api-twitter:"
+	trendCandidateRatio = 5
+)
+
+// Hashtag UseCase
+type hashtagUC struct {
+	cfg              *config.Config
+	hashtagRepo      hashtag.Repository
+	hashtagRedisRepo hashtag.RedisRepository
+	logger           logger.Logger
+}
+
+// Hashtag UseCase constructor
+func NewHashtagUseCase(cfg *config.Config, hashtagRepo hashtag.Repository, hashtagRedisRepo hashtag.RedisRepository, logger logger.Logger) hashtag.UseCase {
+	return &hashtagUC{cfg: cfg, hashtagRepo: hashtagRepo, hashtagRedisRepo: hashtagRedisRepo, logger: logger}
+}
+
+// Store hashtags of a new tweet and count them for trends
+func (u *hashtagUC) AddTweetHashtags(ctx context.Context, tweet *models.Tweet) error {
+	ctx, span := tracer.NewSpan(ctx, "hashtagUC.AddTweetHashtags", nil)
+	defer span.End()
+
+	tags := utils.ExtractHashtags(tweet.Text)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if err := u.hashtagRepo.CreateTweetHashtags(ctx, tweet.ID, tags); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	ttl := int(u.cfg.Trend.BucketSeconds) * (u.cfg.Trend.WindowBuckets + u.cfg.Trend.BaselineBuckets)
+	if err := u.hashtagRedisRepo.IncrTrendsCtx(ctx, u.generateBucketKey(u.currentBucket()), ttl, tags); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("hashtagUC.AddTweetHashtags.IncrTrendsCtx: %v", err)
+	}
+
+	return nil
+}
+
+// Get tweets by hashtag
+func (u *hashtagUC) GetTweetsByHashtag(ctx context.Context, tag string, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "hashtagUC.GetTweetsByHashtag", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "hashtagUC.GetTweetsByHashtag.GetUserFromCtx"))
+	}
+
+	return u.hashtagRepo.GetTweetsByHashtag(ctx, self.UserID, utils.NormalizeHashtag(tag), pq)
+}
+
+// Get trending hashtags.
+// Usage in the recent window is compared against the rate expected from the
+// baseline window before it, so steadily popular hashtags do not dominate.
+func (u *hashtagUC) GetTrends(ctx context.Context) (*models.TrendsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "hashtagUC.GetTrends", nil)
+	defer span.End()
+
+	bucket := u.currentBucket()
+	windowKeys := make([]string, 0, u.cfg.Trend.WindowBuckets)
+	for i := 0; i < u.cfg.Trend.WindowBuckets; i++ {
+		windowKeys = append(windowKeys, u.generateBucketKey(bucket-int64(i)*u.cfg.Trend.BucketSeconds))
+	}
+	baselineKeys := make([]string, 0, u.cfg.Trend.BaselineBuckets)
+	for i := u.cfg.Trend.WindowBuckets; i < u.cfg.Trend.WindowBuckets+u.cfg.Trend.BaselineBuckets; i++ {
+		baselineKeys = append(baselineKeys, u.generateBucketKey(bucket-int64(i)*u.cfg.Trend.BucketSeconds))
+	}
+
+	windowKey := fmt.Sprintf("%s: %s %d", basePrefix, "trends window", bucket)
+	baselineKey := fmt.Sprintf("%s: %s %d", basePrefix, "trends baseline", bucket)
+	if err := u.hashtagRedisRepo.UnionTrendsCtx(ctx, windowKey, int(u.cfg.Trend.BucketSeconds), windowKeys); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	if err := u.hashtagRedisRepo.UnionTrendsCtx(ctx, baselineKey, int(u.cfg.Trend.BucketSeconds), baselineKeys); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	candidates, err := u.hashtagRedisRepo.GetTopTrendsCtx(ctx, windowKey, u.cfg.Trend.Limit*trendCandidateRatio)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		tags = append(tags, c.Hashtag)
+	}
+	baselineCounts, err := u.hashtagRedisRepo.GetTrendCountsCtx(ctx, baselineKey, tags)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	ratio := float64(u.cfg.Trend.WindowBuckets) / float64(u.cfg.Trend.BaselineBuckets)
+	trends := make([]*models.Trend, 0, len(candidates))
+	for i, c := range candidates {
+		if c.TweetCount < u.cfg.Trend.MinCount {
+			continue
+		}
+		expected := float64(baselineCounts[i]) * ratio
+		c.Score = (float64(c.TweetCount) - expected) / math.Sqrt(expected+1)
+		trends = append(trends, c)
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		return trends[i].Score > trends[j].Score
+	})
+	if len(trends) > u.cfg.Trend.Limit {
+		trends = trends[:u.cfg.Trend.Limit]
+	}
+
+	return &models.TrendsList{Trends: trends}, nil
+}
+
+// Start of the current trend bucket in unix seconds
+func (u *hashtagUC) currentBucket() int64 {
+	now := time.Now().Unix()
+	return now - now%u.cfg.Trend.BucketSeconds
+}
+
+func (u *hashtagUC) generateBucketKey(bucket int64) string {
+	return fmt.Sprintf("%s: %s %d", basePrefix, "trends", bucket)
+}
@@ -10,7 +10,7 @@ import (
 
 type Repository interface {
 	Like(ctx context.Context, userID uuid.UUID, tweetID uint64) error
-	GetLikedTweets(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetLikedTweets(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
 	GetLikedUsers(ctx context.Context, selfID uuid.UUID, tweetID uint64, pq *utils.PaginationQuery) (*models.UsersList, error)
 	Delete(ctx context.Context, userID uuid.UUID, tweetID uint64) error
 }
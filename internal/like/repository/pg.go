@@ -44,33 +44,39 @@ func (r *likeRepo) Like(ctx context.Context, userID uuid.UUID, tweetID uint64) e
 	return nil
 }
 
-func (r *likeRepo) GetLikedTweets(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+func (r *likeRepo) GetLikedTweets(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
 	ctx, span := tracer.NewSpan(ctx, "likeRepo.GetLikedTweets", nil)
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotalLikedTweets, userID.String()); err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "likeRepo.GetLikedTweets.GetContext.getTotal")
-	}
-
-	if totalCount == 0 {
-		return &models.TweetsList{
-			TotalCount: totalCount,
-			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
-			Page:       pq.GetPage(),
-			Size:       pq.GetSize(),
-			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
-			Tweets:     make([]*models.TweetWithUser, 0),
-		}, nil
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getTotalLikedTweets, selfID.String(), userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "likeRepo.GetLikedTweets.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
 	}
 
-	var tweets = make([]*models.TweetWithUser, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &tweets, getLikedTweets, userID.String(), pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getLikedTweets, selfID.String(), userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "likeRepo.GetLikedTweets.SelectContext")
 	}
 
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
 	return &models.TweetsList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
@@ -86,28 +92,34 @@ func (r *likeRepo) GetLikedUsers(ctx context.Context, selfID uuid.UUID, tweetID
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotalLikedUsers, tweetID); err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "likeRepo.GetLikedUsers.GetContext.getTotal")
-	}
-
-	if totalCount == 0 {
-		return &models.UsersList{
-			TotalCount: totalCount,
-			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
-			Page:       pq.GetPage(),
-			Size:       pq.GetSize(),
-			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
-			Users:      make([]*models.User, 0),
-		}, nil
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getTotalLikedUsers, tweetID, selfID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "likeRepo.GetLikedUsers.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
 	}
 
-	var users = make([]*models.User, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &users, getLikedUsers, selfID.String(), tweetID, pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getLikedUsers, selfID.String(), tweetID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "likeRepo.GetLikedTweets.SelectContext")
 	}
 
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
 	return &models.UsersList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
@@ -4,27 +4,43 @@ const (
 	likeQuery = `INSERT INTO tweets_likes (user_id, tweet_id, created_at)
 				 VALUES ($1, $2, now())`
 
-	getTotalLikedTweets = `SELECT COUNT(tweet_id)
-						   FROM tweets_likes
-						   WHERE user_id = $1`
+	getTotalLikedTweets = `SELECT COUNT(ll.tweet_id)
+						   FROM tweets_likes ll
+						   INNER JOIN tweets t ON t.id = ll.tweet_id
+						   INNER JOIN users u ON t.user_id = u.user_id
+						   WHERE ll.user_id = $2
+						   AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						   AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)`
 
-	getLikedTweets = `SELECT t.id, t.text, t.image, t.created_at,
+	getLikedTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 					  u.user_id, u.name, u.user_name, u.about, u.avatar,
-					  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, 
-					  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked
+					  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+					  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+					  EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+					  EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+					  (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+					  (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+					  COALESCE(t.conversation_id, t.id) AS conversation_id
 					  FROM tweets t
 					  INNER JOIN users u ON t.user_id = u.user_id
 					  LEFT JOIN tweets_replys r ON t.id = r.tweet_id
-					  LEFT JOIN tweets_likes l ON t.id = l.tweet_id 
-					  WHERE t.id IN (SELECT ll.tweet_id FROM tweets_likes ll WHERE ll.user_id = $1)
-					  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at,
+					  LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+					  LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+					  WHERE t.id IN (SELECT ll.tweet_id FROM tweets_likes ll WHERE ll.user_id = $2)
+					  AND ($3::text IS NULL OR (t.created_at, t.id) < ($3::text::timestamptz, $4::text::bigint))
+					  AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+					  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+					  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 					  u.user_id, u.name, u.user_name, u.about, u.avatar
-					  ORDER BY COALESCE(NULLIF($2, '')::bigint, t.id) desc
-					  OFFSET $3 LIMIT $4`
+					  ORDER BY t.created_at desc, t.id desc
+					  OFFSET $5 LIMIT $6`
 
-	getTotalLikedUsers = `SELECT COUNT(user_id)
-						  FROM tweets_likes
-						  WHERE tweet_id = $1`
+	getTotalLikedUsers = `SELECT COUNT(l.user_id)
+						  FROM tweets_likes l
+						  WHERE l.tweet_id = $1
+						  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $2 AND bl.blocked_id = l.user_id) OR (bl.blocker_id = l.user_id AND bl.blocked_id = $2))`
 
 	getLikedUsers = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
 					 u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
@@ -32,7 +48,9 @@ const (
 					 FROM users u
 					 INNER JOIN tweets_likes l ON l.user_id = u.user_id
 					 WHERE l.tweet_id = $2
-					 ORDER BY COALESCE(NULLIF($3, ''), u.name) OFFSET $4 LIMIT $5
+					 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					 AND ($3::text IS NULL OR (u.name, u.user_id) > ($3::text, $4::text::uuid))
+					 ORDER BY u.name, u.user_id OFFSET $5 LIMIT $6
 					`
 
 	deleteQuery = `DELETE FROM tweets_likes WHERE user_id = $1 AND tweet_id = $2`
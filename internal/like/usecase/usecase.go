@@ -6,6 +6,9 @@ import (
 	"github.com/JamesHsu333/go-twitter/config"
 	"github.com/JamesHsu333/go-twitter/internal/like"
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
 	"github.com/JamesHsu333/go-twitter/pkg/logger"
 	"github.com/JamesHsu333/go-twitter/pkg/tracer"
 	"github.com/JamesHsu333/go-twitter/pkg/utils"
@@ -13,27 +16,63 @@ import (
 )
 
 type likeUC struct {
-	cfg      *config.Config
-	likeRepo like.Repository
-	logger   logger.Logger
+	cfg            *config.Config
+	likeRepo       like.Repository
+	tweetRepo      tweet.Repository
+	notificationUC notification.UseCase
+	streamUC       stream.UseCase
+	logger         logger.Logger
 }
 
-func NewLikeUseCase(cfg *config.Config, likeRepo like.Repository, logger logger.Logger) like.UseCase {
-	return &likeUC{cfg: cfg, likeRepo: likeRepo, logger: logger}
+func NewLikeUseCase(cfg *config.Config, likeRepo like.Repository, tweetRepo tweet.Repository, notificationUC notification.UseCase,
+	streamUC stream.UseCase, logger logger.Logger) like.UseCase {
+	return &likeUC{
+		cfg:            cfg,
+		likeRepo:       likeRepo,
+		tweetRepo:      tweetRepo,
+		notificationUC: notificationUC,
+		streamUC:       streamUC,
+		logger:         logger,
+	}
 }
 
 func (u *likeUC) Like(ctx context.Context, userID uuid.UUID, tweetID uint64) error {
 	ctx, span := tracer.NewSpan(ctx, "likeUC.Like", nil)
 	defer span.End()
 
-	return u.likeRepo.Like(ctx, userID, tweetID)
+	// Tweets hidden from the user by blocks or protection cannot be liked
+	if _, err := u.tweetRepo.GetTweetByID(ctx, userID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if err := u.likeRepo.Like(ctx, userID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	likedTweet, err := u.tweetRepo.GetTweetByID(ctx, userID, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("likeUC.Like.GetTweetByID: %v", err)
+		return nil
+	}
+
+	u.publishLikes(ctx, likedTweet, userID, true)
+
+	if err = u.notificationUC.Notify(ctx, &models.Notification{UserID: likedTweet.UserID, ActorID: userID, Type: models.NotificationLike, TweetID: &tweetID}); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("likeUC.Like.Notify: %v", err)
+	}
+
+	return nil
 }
 
-func (u *likeUC) GetLikedTweets(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+func (u *likeUC) GetLikedTweets(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
 	ctx, span := tracer.NewSpan(ctx, "likeUC.GetLikedTweets", nil)
 	defer span.End()
 
-	return u.likeRepo.GetLikedTweets(ctx, userID, pq)
+	return u.likeRepo.GetLikedTweets(ctx, selfID, userID, pq)
 }
 
 func (u *likeUC) GetLikedUsers(ctx context.Context, selfID uuid.UUID, tweetID uint64, pq *utils.PaginationQuery) (*models.UsersList, error) {
@@ -47,5 +86,32 @@ func (u *likeUC) Delete(ctx context.Context, userID uuid.UUID, tweetID uint64) e
 	ctx, span := tracer.NewSpan(ctx, "likeUC.Delete", nil)
 	defer span.End()
 
-	return u.likeRepo.Delete(ctx, userID, tweetID)
+	if err := u.likeRepo.Delete(ctx, userID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	unlikedTweet, err := u.tweetRepo.GetTweetByID(ctx, userID, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("likeUC.Delete.GetTweetByID: %v", err)
+		return nil
+	}
+
+	u.publishLikes(ctx, unlikedTweet, userID, false)
+
+	return nil
+}
+
+// Push the new like count to the tweet author and the user who liked it
+func (u *likeUC) publishLikes(ctx context.Context, t *models.TweetWithUser, userID uuid.UUID, liked bool) {
+	userIDs := []uuid.UUID{t.UserID}
+	if userID != t.UserID {
+		userIDs = append(userIDs, userID)
+	}
+
+	event := &models.LikeEvent{TweetID: t.ID, UserID: userID, Likes: t.Likes, Liked: liked}
+	if err := u.streamUC.Publish(ctx, models.EventLike, event, userIDs...); err != nil {
+		u.logger.Errorf("likeUC.publishLikes.Publish: %v", err)
+	}
 }
@@ -0,0 +1,19 @@
+package list
+
+import "github.com/labstack/echo/v4"
+
+// List HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetListByID() echo.HandlerFunc
+	GetLists() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	AddMember() echo.HandlerFunc
+	GetMembers() echo.HandlerFunc
+	DeleteMember() echo.HandlerFunc
+	Subscribe() echo.HandlerFunc
+	GetSubscribers() echo.HandlerFunc
+	Unsubscribe() echo.HandlerFunc
+	GetListTweets() echo.HandlerFunc
+}
@@ -0,0 +1,476 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/list"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// List handlers
+type ListHandlers struct {
+	cfg    *config.Config
+	listUC list.UseCase
+	logger logger.Logger
+}
+
+// NewListHandlers List handlers constructor
+func NewListHandlers(cfg *config.Config, listUC list.UseCase, logger logger.Logger) list.Handlers {
+	return &ListHandlers{cfg: cfg, listUC: listUC, logger: logger}
+}
+
+// Create godoc
+// @Summary Create new list
+// @Description Create public or private list owned by current user, returns list
+// @Tags List
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.List
+// @Failure 400 {object} httpErrors.RestError
+// @Router /lists [post]
+func (h *ListHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.Create", nil)
+		defer span.End()
+
+		l := &models.List{}
+		if err := utils.ReadRequest(c, l); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdList, err := h.listUC.Create(ctx, l)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdList)
+	}
+}
+
+// GetListByID godoc
+// @Summary Get list
+// @Description Get list with member and subscriber counts
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 200 {object} models.List
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id} [get]
+func (h *ListHandlers) GetListByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.GetListByID", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		l, err := h.listUC.GetListByID(ctx, listID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, l)
+	}
+}
+
+// GetLists godoc
+// @Summary Get lists
+// @Description Get lists owned or subscribed by current user, most recently updated first
+// @Tags List
+// @Accept json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.ListsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /lists [get]
+func (h *ListHandlers) GetLists() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.GetLists", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		listsList, err := h.listUC.GetLists(ctx, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, listsList)
+	}
+}
+
+// Update godoc
+// @Summary Update list
+// @Description Update name, description or privacy of list owned by current user
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 200 {object} models.List
+// @Failure 403 {object} httpErrors.RestError
+// @Router /lists/{id} [patch]
+func (h *ListHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.Update", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		// Validated by the usecase once merged with the stored list
+		l := &models.List{}
+		if err = c.Bind(l); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		l.ID = listID
+
+		updatedList, err := h.listUC.Update(ctx, l)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedList)
+	}
+}
+
+// Delete godoc
+// @Summary Delete list
+// @Description Delete list owned by current user
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 403 {object} httpErrors.RestError
+// @Router /lists/{id} [delete]
+func (h *ListHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.Delete", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.listUC.Delete(ctx, listID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// AddMember godoc
+// @Summary Add list member
+// @Description Add user to list owned by current user
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 403 {object} httpErrors.RestError
+// @Router /lists/{id}/members [post]
+func (h *ListHandlers) AddMember() echo.HandlerFunc {
+	type Member struct {
+		UserID uuid.UUID `json:"user_id" validate:"required"`
+	}
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.AddMember", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		member := &Member{}
+		if err := utils.ReadRequest(c, member); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.listUC.AddMember(ctx, listID, member.UserID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// GetMembers godoc
+// @Summary Get list members
+// @Description Get the list of users in list
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.UsersList
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/members [get]
+func (h *ListHandlers) GetMembers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.GetMembers", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usersList, err := h.listUC.GetMembers(ctx, listID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usersList)
+	}
+}
+
+// DeleteMember godoc
+// @Summary Remove list member
+// @Description Remove user from list owned by current user
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Param user_id path string true "user_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/members/{user_id} [delete]
+func (h *ListHandlers) DeleteMember() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.DeleteMember", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.listUC.DeleteMember(ctx, listID, userID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Subscribe godoc
+// @Summary Subscribe list
+// @Description Subscribe current user to list of another user
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/subscribers [post]
+func (h *ListHandlers) Subscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.Subscribe", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.listUC.Subscribe(ctx, listID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// GetSubscribers godoc
+// @Summary Get list subscribers
+// @Description Get the list of users subscribed to list
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.UsersList
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/subscribers [get]
+func (h *ListHandlers) GetSubscribers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.GetSubscribers", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usersList, err := h.listUC.GetSubscribers(ctx, listID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usersList)
+	}
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe list
+// @Description Unsubscribe current user from list
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/subscribers [delete]
+func (h *ListHandlers) Unsubscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.Unsubscribe", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.listUC.Unsubscribe(ctx, listID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// GetListTweets godoc
+// @Summary Get list timeline
+// @Description Get tweets of list members, newest first
+// @Tags List
+// @Accept json
+// @Param id path int true "list_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 404 {object} httpErrors.RestError
+// @Router /lists/{id}/tweets [get]
+func (h *ListHandlers) GetListTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "ListHandlers.GetListTweets", nil)
+		defer span.End()
+
+		listID, err := strconv.ParseUint(c.Param("list_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetsList, err := h.listUC.GetListTweets(ctx, listID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweetsList)
+	}
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/list"
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map list routes
+func MapListRoutes(listGroup *echo.Group, h list.Handlers, mw *middleware.MiddlewareManager) {
+	listGroup.Use(mw.AuthSessionMiddleware)
+	listGroup.GET("", h.GetLists())
+	listGroup.POST("", h.Create(), mw.CSRF)
+	listGroup.GET("/:list_id", h.GetListByID())
+	listGroup.PATCH("/:list_id", h.Update(), mw.CSRF)
+	listGroup.DELETE("/:list_id", h.Delete(), mw.CSRF)
+	listGroup.GET("/:list_id/tweets", h.GetListTweets())
+	listGroup.GET("/:list_id/members", h.GetMembers())
+	listGroup.POST("/:list_id/members", h.AddMember(), mw.CSRF)
+	listGroup.DELETE("/:list_id/members/:user_id", h.DeleteMember(), mw.CSRF)
+	listGroup.GET("/:list_id/subscribers", h.GetSubscribers())
+	listGroup.POST("/:list_id/subscribers", h.Subscribe(), mw.CSRF)
+	listGroup.DELETE("/:list_id/subscribers", h.Unsubscribe(), mw.CSRF)
+}
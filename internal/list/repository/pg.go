@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/list"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// List repository
+type listRepo struct {
+	db *sqlx.DB
+}
+
+func NewListRepository(db *sqlx.DB) list.Repository {
+	return &listRepo{db: db}
+}
+
+func (r *listRepo) Create(ctx context.Context, list *models.List) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.Create", nil)
+	defer span.End()
+
+	l := &models.List{}
+	if err := r.db.QueryRowxContext(ctx, createListQuery, &list.OwnerID, &list.Name, &list.Description, list.IsPrivate).StructScan(l); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.Create.StructScan")
+	}
+	return l, nil
+}
+
+// Get list as seen by user, private lists of other users are not found
+func (r *listRepo) GetListByID(ctx context.Context, selfID uuid.UUID, listID uint64) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.GetListByID", nil)
+	defer span.End()
+
+	l := &models.List{}
+	if err := r.db.QueryRowxContext(ctx, getListQuery, selfID.String(), listID).StructScan(l); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetListByID.StructScan")
+	}
+	return l, nil
+}
+
+// Get lists owned or subscribed by user
+func (r *listRepo) GetLists(ctx context.Context, selfID uuid.UUID, pq *utils.PaginationQuery) (*models.ListsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.GetLists", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getListsTotal, selfID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetLists.GetContext.getListsTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.ListsList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Lists:      make([]*models.List, 0),
+		}, nil
+	}
+
+	var lists = make([]*models.List, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &lists, getLists, selfID.String(), pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetLists.SelectContext")
+	}
+
+	return &models.ListsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Lists:      lists,
+	}, nil
+}
+
+func (r *listRepo) Update(ctx context.Context, list *models.List) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.Update", nil)
+	defer span.End()
+
+	l := &models.List{}
+	if err := r.db.GetContext(ctx, l, updateListQuery, list.ID, &list.Name, &list.Description, list.IsPrivate); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.Update.GetContext")
+	}
+
+	return l, nil
+}
+
+func (r *listRepo) Delete(ctx context.Context, listID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.Delete", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteListQuery, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "listRepo.Delete.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "listRepo.Delete.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "listRepo.Delete.rowsAffected")
+	}
+
+	return nil
+}
+
+// Add user to list, adding a member twice is a no-op
+func (r *listRepo) AddMember(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.AddMember", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, addMemberQuery, listID, userID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "listRepo.AddMember.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *listRepo) GetMembers(ctx context.Context, selfID uuid.UUID, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.GetMembers", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getMembersTotal, selfID.String(), listID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "listRepo.GetMembers.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getMembers, selfID.String(), listID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetMembers.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
+	return &models.UsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+func (r *listRepo) DeleteMember(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.DeleteMember", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteMemberQuery, listID, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "listRepo.DeleteMember.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "listRepo.DeleteMember.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "listRepo.DeleteMember.rowsAffected")
+	}
+
+	return nil
+}
+
+// Subscribe user to list, subscribing twice is a no-op
+func (r *listRepo) Subscribe(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.Subscribe", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, subscribeQuery, listID, userID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "listRepo.Subscribe.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *listRepo) GetSubscribers(ctx context.Context, selfID uuid.UUID, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.GetSubscribers", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getSubscribersTotal, selfID.String(), listID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "listRepo.GetSubscribers.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.UsersList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Users:      make([]*models.User, 0),
+			}, nil
+		}
+	}
+
+	var users = make([]*models.User, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &users, getSubscribers, selfID.String(), listID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetSubscribers.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetUsersCursorList(users, pq), nil
+	}
+
+	return &models.UsersList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Users:      users,
+	}, nil
+}
+
+func (r *listRepo) Unsubscribe(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.Unsubscribe", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, unsubscribeQuery, listID, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "listRepo.Unsubscribe.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "listRepo.Unsubscribe.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "listRepo.Unsubscribe.rowsAffected")
+	}
+
+	return nil
+}
+
+// Get tweets of list members, newest first
+func (r *listRepo) GetListTweets(ctx context.Context, selfID uuid.UUID, listID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listRepo.GetListTweets", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getListTweetsTotal, selfID.String(), listID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "listRepo.GetListTweets.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getListTweets, selfID.String(), listID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "listRepo.GetListTweets.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
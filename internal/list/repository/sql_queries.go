@@ -0,0 +1,111 @@
+package repository
+
+const (
+	createListQuery = `INSERT INTO lists (owner_id, name, description, is_private, created_at, updated_at)
+					   VALUES ($1, $2, $3, COALESCE($4, false), now(), now())
+					   RETURNING *`
+
+	getListQuery = `SELECT l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at, l.updated_at,
+				 (SELECT COUNT(lm.user_id) FROM list_members lm WHERE lm.list_id = l.id) AS member_count,
+				 (SELECT COUNT(ls.user_id) FROM list_subscribers ls WHERE ls.list_id = l.id) AS subscriber_count,
+				 EXISTS (SELECT 1 FROM list_subscribers ls WHERE ls.list_id = l.id AND ls.user_id = $1) AS is_subscribed
+				 FROM lists l
+				 WHERE l.id = $2
+				 AND (NOT l.is_private OR l.owner_id = $1)`
+
+	getListsTotal = `SELECT COUNT(l.id) FROM lists l
+					 WHERE (l.owner_id = $1 OR EXISTS (SELECT 1 FROM list_subscribers s WHERE s.list_id = l.id AND s.user_id = $1))
+					 AND (NOT l.is_private OR l.owner_id = $1)`
+
+	getLists = `SELECT l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at, l.updated_at,
+				 (SELECT COUNT(lm.user_id) FROM list_members lm WHERE lm.list_id = l.id) AS member_count,
+				 (SELECT COUNT(ls.user_id) FROM list_subscribers ls WHERE ls.list_id = l.id) AS subscriber_count,
+				 EXISTS (SELECT 1 FROM list_subscribers ls WHERE ls.list_id = l.id AND ls.user_id = $1) AS is_subscribed
+				 FROM lists l
+				 WHERE (l.owner_id = $1 OR EXISTS (SELECT 1 FROM list_subscribers s WHERE s.list_id = l.id AND s.user_id = $1))
+				 AND (NOT l.is_private OR l.owner_id = $1)
+				 ORDER BY l.updated_at desc, l.id desc
+				 OFFSET $2 LIMIT $3`
+
+	updateListQuery = `UPDATE lists
+					   SET name = $2, description = $3, is_private = $4, updated_at = now()
+					   WHERE id = $1
+					   RETURNING *`
+
+	deleteListQuery = `DELETE FROM lists WHERE id = $1`
+
+	addMemberQuery = `INSERT INTO list_members (list_id, user_id, created_at)
+					  VALUES ($1, $2, now())
+					  ON CONFLICT DO NOTHING`
+
+	deleteMemberQuery = `DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`
+
+	getMembersTotal = `SELECT COUNT(lm.user_id) FROM list_members lm
+					   INNER JOIN users u ON u.user_id = lm.user_id
+					   WHERE lm.list_id = $2
+					   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))`
+
+	getMembers = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
+				   u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+				   EXISTS (SELECT 1 FROM follows f where u.user_id = f.following_id and f.follower_id = $1) AS is_following
+				  FROM users u
+				  INNER JOIN list_members lm ON lm.user_id = u.user_id
+				  WHERE lm.list_id = $2
+				  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+				  AND ($3::text IS NULL OR (u.name, u.user_id) > ($3::text, $4::text::uuid))
+				  ORDER BY u.name, u.user_id OFFSET $5 LIMIT $6
+				  `
+
+	subscribeQuery = `INSERT INTO list_subscribers (list_id, user_id, created_at)
+					  VALUES ($1, $2, now())
+					  ON CONFLICT DO NOTHING`
+
+	unsubscribeQuery = `DELETE FROM list_subscribers WHERE list_id = $1 AND user_id = $2`
+
+	getSubscribersTotal = `SELECT COUNT(ls.user_id) FROM list_subscribers ls
+						   INNER JOIN users u ON u.user_id = ls.user_id
+						   WHERE ls.list_id = $2
+						   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))`
+
+	getSubscribers = `SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
+				   u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+				   EXISTS (SELECT 1 FROM follows f where u.user_id = f.following_id and f.follower_id = $1) AS is_following
+					  FROM users u
+					  INNER JOIN list_subscribers ls ON ls.user_id = u.user_id
+					  WHERE ls.list_id = $2
+					  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					  AND ($3::text IS NULL OR (u.name, u.user_id) > ($3::text, $4::text::uuid))
+					  ORDER BY u.name, u.user_id OFFSET $5 LIMIT $6
+					  `
+
+	getListTweetsTotal = `SELECT COUNT(t.id) FROM tweets t
+						  INNER JOIN users u ON t.user_id = u.user_id
+						  WHERE u.user_id IN (SELECT lm.user_id FROM list_members lm WHERE lm.list_id = $2)
+						  AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)`
+
+	getListTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					 u.user_id, u.name, u.user_name, u.about, u.avatar,
+					 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+					 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+					 EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+					 EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+					 (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+					 (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+					 COALESCE(t.conversation_id, t.id) AS conversation_id
+					 FROM tweets t
+					 INNER JOIN users u ON t.user_id = u.user_id
+					 LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+					 LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+					 LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+					 WHERE u.user_id IN (SELECT lm.user_id FROM list_members lm WHERE lm.list_id = $2)
+					 AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+					 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					 AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+					 AND ($3::text IS NULL OR (t.created_at, t.id) < ($3::text::timestamptz, $4::text::bigint))
+					 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					 u.user_id, u.name, u.user_name, u.about, u.avatar
+					 ORDER BY t.created_at desc, t.id desc
+					 OFFSET $5 LIMIT $6`
+)
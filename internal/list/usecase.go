@@ -0,0 +1,25 @@
+package list
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// List usecase interface
+type UseCase interface {
+	Create(ctx context.Context, list *models.List) (*models.List, error)
+	GetListByID(ctx context.Context, listID uint64) (*models.List, error)
+	GetLists(ctx context.Context, pq *utils.PaginationQuery) (*models.ListsList, error)
+	Update(ctx context.Context, list *models.List) (*models.List, error)
+	Delete(ctx context.Context, listID uint64) error
+	AddMember(ctx context.Context, listID uint64, userID uuid.UUID) error
+	GetMembers(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error)
+	DeleteMember(ctx context.Context, listID uint64, userID uuid.UUID) error
+	Subscribe(ctx context.Context, listID uint64) error
+	GetSubscribers(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error)
+	Unsubscribe(ctx context.Context, listID uint64) error
+	GetListTweets(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error)
+}
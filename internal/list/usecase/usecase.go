@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/block"
+	"github.com/JamesHsu333/go-twitter/internal/list"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// List Usecase
+type listUC struct {
+	cfg       *config.Config
+	listRepo  list.Repository
+	blockRepo block.Repository
+	logger    logger.Logger
+}
+
+// New Usecase
+func NewListUseCase(cfg *config.Config, listRepo list.Repository, blockRepo block.Repository, logger logger.Logger) list.UseCase {
+	return &listUC{
+		cfg:       cfg,
+		listRepo:  listRepo,
+		blockRepo: blockRepo,
+		logger:    logger,
+	}
+}
+
+// Create new list owned by current user
+func (u *listUC) Create(ctx context.Context, list *models.List) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.Create", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.Create.GetUserFromCtx"))
+	}
+
+	list.OwnerID = self.UserID
+	if err = utils.ValidateStruct(ctx, list); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "listUC.Create.ValidateStruct"))
+	}
+
+	return u.listRepo.Create(ctx, list)
+}
+
+func (u *listUC) GetListByID(ctx context.Context, listID uint64) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.GetListByID", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.GetListByID.GetUserFromCtx"))
+	}
+
+	return u.listRepo.GetListByID(ctx, self.UserID, listID)
+}
+
+// Get lists owned or subscribed by current user
+func (u *listUC) GetLists(ctx context.Context, pq *utils.PaginationQuery) (*models.ListsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.GetLists", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.GetLists.GetUserFromCtx"))
+	}
+
+	return u.listRepo.GetLists(ctx, self.UserID, pq)
+}
+
+// Update name, description or privacy of list, fields left empty are kept
+func (u *listUC) Update(ctx context.Context, list *models.List) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.Update", nil)
+	defer span.End()
+
+	existing, err := u.getOwnedList(ctx, list.ID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if list.Name != "" {
+		existing.Name = list.Name
+	}
+	if list.Description != nil {
+		existing.Description = list.Description
+	}
+	if list.IsPrivate != nil {
+		existing.IsPrivate = list.IsPrivate
+	}
+
+	if err = utils.ValidateStruct(ctx, existing); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "listUC.Update.ValidateStruct"))
+	}
+
+	return u.listRepo.Update(ctx, existing)
+}
+
+func (u *listUC) Delete(ctx context.Context, listID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "listUC.Delete", nil)
+	defer span.End()
+
+	if _, err := u.getOwnedList(ctx, listID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	return u.listRepo.Delete(ctx, listID)
+}
+
+// Add user to list of current user, users blocked either way cannot be added
+func (u *listUC) AddMember(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listUC.AddMember", nil)
+	defer span.End()
+
+	l, err := u.getOwnedList(ctx, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	isBlocked, err := u.blockRepo.IsBlocked(ctx, l.OwnerID, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+	if isBlocked {
+		err = errors.Errorf("user %s is blocked", userID)
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewForbiddenError(errors.WithMessage(err, "listUC.AddMember.IsBlocked"))
+	}
+
+	if err = u.listRepo.AddMember(ctx, listID, userID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("listUC.AddMember.AddMember: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *listUC) GetMembers(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.GetMembers", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.GetMembers.GetUserFromCtx"))
+	}
+
+	l, err := u.listRepo.GetListByID(ctx, self.UserID, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.listRepo.GetMembers(ctx, self.UserID, l.ID, pq)
+}
+
+func (u *listUC) DeleteMember(ctx context.Context, listID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "listUC.DeleteMember", nil)
+	defer span.End()
+
+	if _, err := u.getOwnedList(ctx, listID); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	return u.listRepo.DeleteMember(ctx, listID, userID)
+}
+
+// Subscribe current user to list of another user
+func (u *listUC) Subscribe(ctx context.Context, listID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "listUC.Subscribe", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.Subscribe.GetUserFromCtx"))
+	}
+
+	l, err := u.listRepo.GetListByID(ctx, self.UserID, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+	if l.OwnerID == self.UserID {
+		err = errors.New("owner cannot subscribe to own list")
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewBadRequestError(errors.WithMessage(err, "listUC.Subscribe"))
+	}
+
+	return u.listRepo.Subscribe(ctx, listID, self.UserID)
+}
+
+func (u *listUC) GetSubscribers(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.UsersList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.GetSubscribers", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.GetSubscribers.GetUserFromCtx"))
+	}
+
+	l, err := u.listRepo.GetListByID(ctx, self.UserID, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.listRepo.GetSubscribers(ctx, self.UserID, l.ID, pq)
+}
+
+func (u *listUC) Unsubscribe(ctx context.Context, listID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "listUC.Unsubscribe", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.Unsubscribe.GetUserFromCtx"))
+	}
+
+	return u.listRepo.Unsubscribe(ctx, listID, self.UserID)
+}
+
+// Get timeline of tweets from list members
+func (u *listUC) GetListTweets(ctx context.Context, listID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.GetListTweets", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.GetListTweets.GetUserFromCtx"))
+	}
+
+	// Private lists of other users are not found
+	l, err := u.listRepo.GetListByID(ctx, self.UserID, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.listRepo.GetListTweets(ctx, self.UserID, l.ID, pq)
+}
+
+// Get list of current user, lists of other users are forbidden
+func (u *listUC) getOwnedList(ctx context.Context, listID uint64) (*models.List, error) {
+	ctx, span := tracer.NewSpan(ctx, "listUC.getOwnedList", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "listUC.getOwnedList.GetUserFromCtx"))
+	}
+
+	l, err := u.listRepo.GetListByID(ctx, self.UserID, listID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	if l.OwnerID != self.UserID {
+		err = errors.Errorf("list %d is owned by another user", listID)
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewForbiddenError(errors.WithMessage(err, "listUC.getOwnedList"))
+	}
+
+	return l, nil
+}
@@ -0,0 +1,10 @@
+package media
+
+import "github.com/labstack/echo/v4"
+
+// Media HTTP Handlers interface
+type Handlers interface {
+	Upload() echo.HandlerFunc
+	GetMediaByID() echo.HandlerFunc
+	UpdateAltText() echo.HandlerFunc
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/media"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Media handlers
+type MediaHandlers struct {
+	cfg     *config.Config
+	mediaUC media.UseCase
+	logger  logger.Logger
+}
+
+// NewMediaHandlers Media handlers constructor
+func NewMediaHandlers(cfg *config.Config, mediaUC media.UseCase, logger logger.Logger) media.Handlers {
+	return &MediaHandlers{cfg: cfg, mediaUC: mediaUC, logger: logger}
+}
+
+// Upload godoc
+// @Summary Upload media
+// @Description Upload image before creating a tweet, the returned media_id is referenced on tweet create
+// @Tags Media
+// @Accept file formData file true "Body with media file"
+// @Param alt_text formData string false "alt text of the image"
+// @Produce json
+// @Success 201 {object} models.Media
+// @Failure 400 {object} httpErrors.RestError
+// @Router /media [post]
+func (h *MediaHandlers) Upload() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MediaHandlers.Upload", nil)
+		defer span.End()
+
+		image, err := utils.ReadImage(c, "media")
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		content, err := utils.ReadFile(image)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		var altText *string
+		if value := c.FormValue("alt_text"); value != "" {
+			altText = &value
+		}
+
+		uploadedMedia, err := h.mediaUC.Upload(ctx, content, altText)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, uploadedMedia)
+	}
+}
+
+// GetMediaByID godoc
+// @Summary Get media
+// @Description Get media uploaded by current user
+// @Tags Media
+// @Accept json
+// @Param id path int true "media_id"
+// @Produce json
+// @Success 200 {object} models.Media
+// @Failure 404 {object} httpErrors.RestError
+// @Router /media/{id} [get]
+func (h *MediaHandlers) GetMediaByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MediaHandlers.GetMediaByID", nil)
+		defer span.End()
+
+		mediaID, err := strconv.ParseUint(c.Param("media_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		m, err := h.mediaUC.GetMediaByID(ctx, mediaID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, m)
+	}
+}
+
+// UpdateAltText godoc
+// @Summary Update media alt text
+// @Description Update alt text of media uploaded by current user
+// @Tags Media
+// @Accept json
+// @Param id path int true "media_id"
+// @Produce json
+// @Success 200 {object} models.Media
+// @Failure 404 {object} httpErrors.RestError
+// @Router /media/{id} [patch]
+func (h *MediaHandlers) UpdateAltText() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MediaHandlers.UpdateAltText", nil)
+		defer span.End()
+
+		mediaID, err := strconv.ParseUint(c.Param("media_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		m := &models.Media{}
+		if err = utils.ReadRequest(c, m); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		m.ID = mediaID
+
+		updatedMedia, err := h.mediaUC.UpdateAltText(ctx, m)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedMedia)
+	}
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/media"
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map media routes
+func MapMediaRoutes(mediaGroup *echo.Group, h media.Handlers, mw *middleware.MiddlewareManager) {
+	mediaGroup.Use(mw.AuthSessionMiddleware)
+	mediaGroup.POST("", h.Upload(), mw.CSRF)
+	mediaGroup.GET("/:media_id", h.GetMediaByID())
+	mediaGroup.PATCH("/:media_id", h.UpdateAltText(), mw.CSRF)
+}
@@ -0,0 +1,15 @@
+package media
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
+)
+
+// Media repository interface
+type Repository interface {
+	Create(ctx context.Context, media *models.Media) (*models.Media, error)
+	GetMediaByID(ctx context.Context, userID uuid.UUID, mediaID uint64) (*models.Media, error)
+	UpdateAltText(ctx context.Context, media *models.Media) (*models.Media, error)
+}
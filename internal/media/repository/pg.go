@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/media"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Media repository
+type mediaRepo struct {
+	db *sqlx.DB
+}
+
+func NewMediaRepository(db *sqlx.DB) media.Repository {
+	return &mediaRepo{db: db}
+}
+
+func (r *mediaRepo) Create(ctx context.Context, media *models.Media) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaRepo.Create", nil)
+	defer span.End()
+
+	m := &models.Media{}
+	if err := r.db.QueryRowxContext(ctx, createMediaQuery, &media.UserID, &media.URL, media.Width, media.Height, media.BlurHash, media.AltText).StructScan(m); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "mediaRepo.Create.StructScan")
+	}
+	return m, nil
+}
+
+func (r *mediaRepo) GetMediaByID(ctx context.Context, userID uuid.UUID, mediaID uint64) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaRepo.GetMediaByID", nil)
+	defer span.End()
+
+	m := &models.Media{}
+	if err := r.db.QueryRowxContext(ctx, getMediaQuery, mediaID, userID).StructScan(m); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "mediaRepo.GetMediaByID.StructScan")
+	}
+	return m, nil
+}
+
+func (r *mediaRepo) UpdateAltText(ctx context.Context, media *models.Media) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaRepo.UpdateAltText", nil)
+	defer span.End()
+
+	m := &models.Media{}
+	if err := r.db.GetContext(ctx, m, updateAltTextQuery, media.ID, media.UserID, media.AltText); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "mediaRepo.UpdateAltText.GetContext")
+	}
+
+	return m, nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+const (
+	createMediaQuery = `INSERT INTO tweet_media (user_id, url, width, height, blurhash, alt_text, created_at)
+						VALUES ($1, $2, $3, $4, $5, $6, now())
+						RETURNING *`
+
+	getMediaQuery = `SELECT * FROM tweet_media WHERE id = $1 AND user_id = $2`
+
+	updateAltTextQuery = `UPDATE tweet_media SET alt_text = $3
+						  WHERE id = $1 AND user_id = $2
+						  RETURNING *`
+)
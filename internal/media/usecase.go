@@ -0,0 +1,14 @@
+package media
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+)
+
+// Media usecase interface
+type UseCase interface {
+	Upload(ctx context.Context, content []byte, altText *string) (*models.Media, error)
+	GetMediaByID(ctx context.Context, mediaID uint64) (*models.Media, error)
+	UpdateAltText(ctx context.Context, media *models.Media) (*models.Media, error)
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/media"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// Media Usecase
+type mediaUC struct {
+	cfg       *config.Config
+	mediaRepo media.Repository
+	fileUC    file.UseCase
+	logger    logger.Logger
+}
+
+// New Usecase
+func NewMediaUseCase(cfg *config.Config, mediaRepo media.Repository, fileUC file.UseCase, logger logger.Logger) media.UseCase {
+	return &mediaUC{
+		cfg:       cfg,
+		mediaRepo: mediaRepo,
+		fileUC:    fileUC,
+		logger:    logger,
+	}
+}
+
+// Store image of current user, it stays unattached until referenced by media_id on tweet create
+func (u *mediaUC) Upload(ctx context.Context, content []byte, altText *string) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaUC.Upload", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "mediaUC.Upload.GetUserFromCtx"))
+	}
+
+	m := &models.Media{UserID: self.UserID, AltText: altText}
+	if err = utils.ValidateStruct(ctx, m); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "mediaUC.Upload.ValidateStruct"))
+	}
+
+	if _, err = utils.CheckImageFileContentType(content); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "mediaUC.Upload.CheckImageFileContentType"))
+	}
+
+	image, err := u.fileUC.PutImage(ctx, content)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	m.URL = image.URL
+	m.Width = &image.Width
+	m.Height = &image.Height
+	m.BlurHash = &image.BlurHash
+
+	createdMedia, err := u.mediaRepo.Create(ctx, m)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if rmErr := u.fileUC.RemoveImage(ctx, self.UserID, image.URL); rmErr != nil {
+			tracer.AddSpanError(span, rmErr)
+			u.logger.Errorf("mediaUC.Upload.RemoveImage: %v", rmErr)
+		}
+		return nil, err
+	}
+	createdMedia.Renditions = image.Renditions
+
+	return createdMedia, nil
+}
+
+func (u *mediaUC) GetMediaByID(ctx context.Context, mediaID uint64) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaUC.GetMediaByID", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "mediaUC.GetMediaByID.GetUserFromCtx"))
+	}
+
+	m, err := u.mediaRepo.GetMediaByID(ctx, self.UserID, mediaID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	m.Renditions = utils.GetImageRenditions(m.URL)
+
+	return m, nil
+}
+
+// Update alt text of media uploaded by current user
+func (u *mediaUC) UpdateAltText(ctx context.Context, media *models.Media) (*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "mediaUC.UpdateAltText", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "mediaUC.UpdateAltText.GetUserFromCtx"))
+	}
+
+	media.UserID = self.UserID
+	if err = utils.ValidateStruct(ctx, media); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "mediaUC.UpdateAltText.ValidateStruct"))
+	}
+
+	updatedMedia, err := u.mediaRepo.UpdateAltText(ctx, media)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	updatedMedia.Renditions = utils.GetImageRenditions(updatedMedia.URL)
+
+	return updatedMedia, nil
+}
@@ -0,0 +1,13 @@
+package message
+
+import "github.com/labstack/echo/v4"
+
+// Message HTTP Handlers interface
+type Handlers interface {
+	CreateConversation() echo.HandlerFunc
+	GetConversation() echo.HandlerFunc
+	GetConversations() echo.HandlerFunc
+	CreateMessage() echo.HandlerFunc
+	GetMessages() echo.HandlerFunc
+	MarkRead() echo.HandlerFunc
+}
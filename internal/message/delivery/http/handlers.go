@@ -0,0 +1,291 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/message"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Message handlers
+type MessageHandlers struct {
+	cfg       *config.Config
+	messageUC message.UseCase
+	fileUC    file.UseCase
+	logger    logger.Logger
+}
+
+// NewMessageHandlers Message handlers constructor
+func NewMessageHandlers(cfg *config.Config, messageUC message.UseCase, fileUC file.UseCase, logger logger.Logger) message.Handlers {
+	return &MessageHandlers{cfg: cfg, messageUC: messageUC, fileUC: fileUC, logger: logger}
+}
+
+// CreateConversation godoc
+// @Summary Create new conversation
+// @Description Start a direct or group conversation with users, returns the existing direct conversation if there is one
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Conversation
+// @Failure 403 {object} httpErrors.RestError
+// @Router /conversations [post]
+func (h *MessageHandlers) CreateConversation() echo.HandlerFunc {
+	type Conversation struct {
+		UserIDs []uuid.UUID `json:"user_ids" validate:"required,min=1"`
+		Name    *string     `json:"name" validate:"omitempty,lte=64"`
+	}
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.CreateConversation", nil)
+		defer span.End()
+
+		conversation := &Conversation{}
+		if err := utils.ReadRequest(c, conversation); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdConversation, err := h.messageUC.CreateConversation(ctx, conversation.UserIDs, conversation.Name)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdConversation)
+	}
+}
+
+// GetConversation godoc
+// @Summary Get conversation
+// @Description Get conversation of current user with its members
+// @Tags Message
+// @Accept json
+// @Param id path int true "conversation_id"
+// @Produce json
+// @Success 200 {object} models.Conversation
+// @Failure 404 {object} httpErrors.RestError
+// @Router /conversations/{id} [get]
+func (h *MessageHandlers) GetConversation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.GetConversation", nil)
+		defer span.End()
+
+		conversationID, err := strconv.ParseUint(c.Param("conversation_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		conversation, err := h.messageUC.GetConversation(ctx, conversationID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, conversation)
+	}
+}
+
+// GetConversations godoc
+// @Summary Get conversations
+// @Description Get the list of conversations of current user, most recently active first
+// @Tags Message
+// @Accept json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.ConversationsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /conversations [get]
+func (h *MessageHandlers) GetConversations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.GetConversations", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		conversationsList, err := h.messageUC.GetConversations(ctx, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, conversationsList)
+	}
+}
+
+// CreateMessage godoc
+// @Summary Send message
+// @Description Send message with text or image to conversation, returns message
+// @Tags Message
+// @Accept file formData file true "Body with image file"
+// @Param id path int true "conversation_id"
+// @Produce json
+// @Success 201 {object} models.Message
+// @Failure 404 {object} httpErrors.RestError
+// @Router /conversations/{id}/messages [post]
+func (h *MessageHandlers) CreateMessage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.CreateMessage", nil)
+		defer span.End()
+
+		conversationID, err := strconv.ParseUint(c.Param("conversation_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		message := &models.Message{}
+		if err = utils.ReadRequest(c, message); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		image, err := utils.ReadImage(c, "image")
+		if err != nil {
+			if !strings.Contains(err.Error(), "no such file") {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
+		if image != nil {
+			file, err := image.Open()
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+			defer file.Close()
+
+			binaryImage := bytes.NewBuffer(nil)
+			if _, err = io.Copy(binaryImage, file); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			if _, err = utils.CheckImageFileContentType(binaryImage.Bytes()); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			uploadedImage, err := h.fileUC.PutImage(ctx, binaryImage.Bytes())
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			message.Image = &uploadedImage.URL
+		}
+
+		createdMessage, err := h.messageUC.CreateMessage(ctx, conversationID, message)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdMessage)
+	}
+}
+
+// GetMessages godoc
+// @Summary Get messages
+// @Description Get the list of messages of conversation, newest first
+// @Tags Message
+// @Accept json
+// @Param id path int true "conversation_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.MessagesList
+// @Failure 404 {object} httpErrors.RestError
+// @Router /conversations/{id}/messages [get]
+func (h *MessageHandlers) GetMessages() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.GetMessages", nil)
+		defer span.End()
+
+		conversationID, err := strconv.ParseUint(c.Param("conversation_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		messagesList, err := h.messageUC.GetMessages(ctx, conversationID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, messagesList)
+	}
+}
+
+// MarkRead godoc
+// @Summary Mark conversation as read
+// @Description Move the read receipt of current user to the latest message of conversation
+// @Tags Message
+// @Accept json
+// @Param id path int true "conversation_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /conversations/{id}/read [post]
+func (h *MessageHandlers) MarkRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "MessageHandlers.MarkRead", nil)
+		defer span.End()
+
+		conversationID, err := strconv.ParseUint(c.Param("conversation_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.messageUC.MarkRead(ctx, conversationID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/message"
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map message routes
+func MapMessageRoutes(conversationGroup *echo.Group, h message.Handlers, mw *middleware.MiddlewareManager) {
+	conversationGroup.Use(mw.AuthSessionMiddleware)
+	conversationGroup.GET("", h.GetConversations())
+	conversationGroup.POST("", h.CreateConversation(), mw.CSRF)
+	conversationGroup.GET("/:conversation_id", h.GetConversation())
+	conversationGroup.GET("/:conversation_id/messages", h.GetMessages())
+	conversationGroup.POST("/:conversation_id/messages", h.CreateMessage(), mw.CSRF)
+	conversationGroup.POST("/:conversation_id/read", h.MarkRead(), mw.CSRF)
+}
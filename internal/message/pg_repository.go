@@ -0,0 +1,22 @@
+package message
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Message repository interface
+type Repository interface {
+	CreateConversation(ctx context.Context, conversation *models.Conversation, memberIDs []uuid.UUID) (*models.Conversation, error)
+	GetDirectConversationID(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (uint64, error)
+	GetConversation(ctx context.Context, userID uuid.UUID, conversationID uint64) (*models.Conversation, error)
+	GetConversations(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.ConversationsList, error)
+	GetMembers(ctx context.Context, conversationIDs []uint64) ([]*models.ConversationMember, error)
+	CheckAllowDMs(ctx context.Context, userID uuid.UUID) (bool, error)
+	CreateMessage(ctx context.Context, message *models.Message) (*models.Message, error)
+	GetMessages(ctx context.Context, conversationID uint64, pq *utils.PaginationQuery) (*models.MessagesList, error)
+	MarkRead(ctx context.Context, conversationID uint64, userID uuid.UUID) error
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/message"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Message repository
+type messageRepo struct {
+	db *sqlx.DB
+}
+
+func NewMessageRepository(db *sqlx.DB) message.Repository {
+	return &messageRepo{db: db}
+}
+
+// Create conversation with its members
+func (r *messageRepo) CreateConversation(ctx context.Context, conversation *models.Conversation, memberIDs []uuid.UUID) (*models.Conversation, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.CreateConversation", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.CreateConversation.BeginTxx")
+	}
+
+	c := &models.Conversation{}
+	if err = tx.QueryRowxContext(ctx, createConversationQuery, conversation.CreatedBy, conversation.IsGroup, conversation.Name).StructScan(c); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.Wrap(err, "messageRepo.CreateConversation.QueryRowxContext")
+	}
+
+	for _, memberID := range memberIDs {
+		if _, err = tx.ExecContext(ctx, createMemberQuery, c.ID, memberID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return nil, errors.Wrap(err, "messageRepo.CreateConversation.ExecContext")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.CreateConversation.Commit")
+	}
+
+	return c, nil
+}
+
+func (r *messageRepo) GetDirectConversationID(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (uint64, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.GetDirectConversationID", nil)
+	defer span.End()
+
+	var conversationID uint64
+	if err := r.db.GetContext(ctx, &conversationID, getDirectConversationID, userID.String(), otherID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "messageRepo.GetDirectConversationID.GetContext")
+	}
+
+	return conversationID, nil
+}
+
+// Get conversation as seen by a member
+func (r *messageRepo) GetConversation(ctx context.Context, userID uuid.UUID, conversationID uint64) (*models.Conversation, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.GetConversation", nil)
+	defer span.End()
+
+	c := &models.Conversation{}
+	if err := r.db.QueryRowxContext(ctx, getConversationQuery, userID.String(), conversationID).StructScan(c); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetConversation.QueryRowxContext")
+	}
+
+	return c, nil
+}
+
+func (r *messageRepo) GetConversations(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.ConversationsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.GetConversations", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getConversationsTotal, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetConversations.GetContext.getConversationsTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.ConversationsList{
+			TotalCount:    totalCount,
+			TotalPages:    utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:          pq.GetPage(),
+			Size:          pq.GetSize(),
+			HasMore:       utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Conversations: make([]*models.Conversation, 0),
+		}, nil
+	}
+
+	var conversations = make([]*models.Conversation, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &conversations, getConversations, userID.String(), pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetConversations.SelectContext")
+	}
+
+	return &models.ConversationsList{
+		TotalCount:    totalCount,
+		TotalPages:    utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:          pq.GetPage(),
+		Size:          pq.GetSize(),
+		HasMore:       utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Conversations: conversations,
+	}, nil
+}
+
+func (r *messageRepo) GetMembers(ctx context.Context, conversationIDs []uint64) ([]*models.ConversationMember, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.GetMembers", nil)
+	defer span.End()
+
+	var members = make([]*models.ConversationMember, 0)
+	if len(conversationIDs) == 0 {
+		return members, nil
+	}
+
+	query, args, err := sqlx.In(getMembers, conversationIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetMembers.sqlx.In")
+	}
+
+	if err := r.db.SelectContext(ctx, &members, r.db.Rebind(query), args...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetMembers.SelectContext")
+	}
+
+	return members, nil
+}
+
+// Check whether user accepts direct messages from anyone
+func (r *messageRepo) CheckAllowDMs(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.CheckAllowDMs", nil)
+	defer span.End()
+
+	var allowDMs bool
+	if err := r.db.GetContext(ctx, &allowDMs, checkAllowDMs, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "messageRepo.CheckAllowDMs.GetContext")
+	}
+
+	return allowDMs, nil
+}
+
+// Create message, marking it read for its sender
+func (r *messageRepo) CreateMessage(ctx context.Context, message *models.Message) (*models.Message, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.CreateMessage", nil)
+	defer span.End()
+
+	m := &models.Message{}
+	if err := r.db.QueryRowxContext(ctx, createMessageQuery, message.ConversationID, message.UserID.String(),
+		message.Text, message.Image,
+	).StructScan(m); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.CreateMessage.QueryRowxContext")
+	}
+
+	return m, nil
+}
+
+func (r *messageRepo) GetMessages(ctx context.Context, conversationID uint64, pq *utils.PaginationQuery) (*models.MessagesList, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.GetMessages", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getMessagesTotal, conversationID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetMessages.GetContext.getMessagesTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.MessagesList{
+			TotalCount: totalCount,
+			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:       pq.GetPage(),
+			Size:       pq.GetSize(),
+			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Messages:   make([]*models.Message, 0),
+		}, nil
+	}
+
+	var messages = make([]*models.Message, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &messages, getMessages, conversationID, pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "messageRepo.GetMessages.SelectContext")
+	}
+
+	return &models.MessagesList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Messages:   messages,
+	}, nil
+}
+
+// Mark every message of the conversation read for user
+func (r *messageRepo) MarkRead(ctx context.Context, conversationID uint64, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "messageRepo.MarkRead", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, markReadQuery, conversationID, userID.String())
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "messageRepo.MarkRead.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "messageRepo.MarkRead.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "messageRepo.MarkRead.rowsAffected")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+const (
+	createConversationQuery = `INSERT INTO conversations (created_by, is_group, name, created_at, updated_at)
+							   VALUES ($1, $2, $3, now(), now())
+							   RETURNING *`
+
+	createMemberQuery = `INSERT INTO conversation_members (conversation_id, user_id, joined_at)
+						 VALUES ($1, $2, now())
+						 ON CONFLICT DO NOTHING`
+
+	getDirectConversationID = `SELECT c.id FROM conversations c
+							   INNER JOIN conversation_members m1 ON m1.conversation_id = c.id AND m1.user_id = $1
+							   INNER JOIN conversation_members m2 ON m2.conversation_id = c.id AND m2.user_id = $2
+							   WHERE c.is_group = false
+							   LIMIT 1`
+
+	getConversationQuery = `SELECT c.id, c.created_by, c.is_group, c.name, c.created_at, c.updated_at,
+							lm.id AS last_message_id, lm.text AS last_message_text, lm.created_at AS last_message_at,
+							(SELECT COUNT(msg.id) FROM messages msg
+							 WHERE msg.conversation_id = c.id AND msg.id > m.last_read_message_id AND msg.user_id <> m.user_id) AS unread_count
+							FROM conversation_members m
+							INNER JOIN conversations c ON c.id = m.conversation_id
+							LEFT JOIN LATERAL (SELECT l.id, l.text, l.created_at FROM messages l
+											   WHERE l.conversation_id = c.id ORDER BY l.id desc LIMIT 1) lm ON true
+							WHERE m.user_id = $1 AND c.id = $2`
+
+	getConversationsTotal = `SELECT COUNT(conversation_id) FROM conversation_members WHERE user_id = $1`
+
+	getConversations = `SELECT c.id, c.created_by, c.is_group, c.name, c.created_at, c.updated_at,
+						lm.id AS last_message_id, lm.text AS last_message_text, lm.created_at AS last_message_at,
+						(SELECT COUNT(msg.id) FROM messages msg
+						 WHERE msg.conversation_id = c.id AND msg.id > m.last_read_message_id AND msg.user_id <> m.user_id) AS unread_count
+						FROM conversation_members m
+						INNER JOIN conversations c ON c.id = m.conversation_id
+						LEFT JOIN LATERAL (SELECT l.id, l.text, l.created_at FROM messages l
+										   WHERE l.conversation_id = c.id ORDER BY l.id desc LIMIT 1) lm ON true
+						WHERE m.user_id = $1
+						ORDER BY c.updated_at desc, c.id desc
+						OFFSET $2 LIMIT $3`
+
+	getMembers = `SELECT m.conversation_id, m.user_id, u.user_name, u.name, u.avatar, m.last_read_message_id, m.joined_at
+				  FROM conversation_members m
+				  INNER JOIN users u ON u.user_id = m.user_id
+				  WHERE m.conversation_id IN (?)
+				  ORDER BY m.joined_at, u.user_name`
+
+	checkAllowDMs = `SELECT allow_dms FROM users WHERE user_id = $1`
+
+	createMessageQuery = `WITH __m AS
+							(INSERT INTO messages (conversation_id, user_id, text, image, created_at)
+							VALUES ($1, $2, $3, $4, now())
+							RETURNING *
+							),
+						  __c AS
+							(UPDATE conversations SET updated_at = now() WHERE id = $1
+							),
+						  __r AS
+							(UPDATE conversation_members cm SET last_read_message_id = __m.id
+							FROM __m WHERE cm.conversation_id = __m.conversation_id AND cm.user_id = __m.user_id
+							)
+						  SELECT * FROM __m`
+
+	getMessagesTotal = `SELECT COUNT(id) FROM messages WHERE conversation_id = $1`
+
+	getMessages = `SELECT id, conversation_id, user_id, text, image, created_at
+				   FROM messages
+				   WHERE conversation_id = $1
+				   ORDER BY id desc
+				   OFFSET $2 LIMIT $3`
+
+	markReadQuery = `UPDATE conversation_members
+					 SET last_read_message_id = GREATEST(last_read_message_id,
+						COALESCE((SELECT MAX(id) FROM messages WHERE conversation_id = $1), 0))
+					 WHERE conversation_id = $1 AND user_id = $2`
+)
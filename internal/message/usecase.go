@@ -0,0 +1,19 @@
+package message
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Message usecase interface
+type UseCase interface {
+	CreateConversation(ctx context.Context, userIDs []uuid.UUID, name *string) (*models.Conversation, error)
+	GetConversation(ctx context.Context, conversationID uint64) (*models.Conversation, error)
+	GetConversations(ctx context.Context, pq *utils.PaginationQuery) (*models.ConversationsList, error)
+	CreateMessage(ctx context.Context, conversationID uint64, message *models.Message) (*models.Message, error)
+	GetMessages(ctx context.Context, conversationID uint64, pq *utils.PaginationQuery) (*models.MessagesList, error)
+	MarkRead(ctx context.Context, conversationID uint64) error
+}
@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/block"
+	"github.com/JamesHsu333/go-twitter/internal/follow"
+	"github.com/JamesHsu333/go-twitter/internal/message"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Message UseCase
+type messageUC struct {
+	cfg         *config.Config
+	messageRepo message.Repository
+	followRepo  follow.Repository
+	blockRepo   block.Repository
+	streamUC    stream.UseCase
+	logger      logger.Logger
+}
+
+// Message UseCase constructor
+func NewMessageUseCase(cfg *config.Config, messageRepo message.Repository, followRepo follow.Repository, blockRepo block.Repository, streamUC stream.UseCase, logger logger.Logger) message.UseCase {
+	return &messageUC{cfg: cfg, messageRepo: messageRepo, followRepo: followRepo, blockRepo: blockRepo, streamUC: streamUC, logger: logger}
+}
+
+// Start conversation with users. A direct conversation that already exists is returned instead.
+func (u *messageUC) CreateConversation(ctx context.Context, userIDs []uuid.UUID, name *string) (*models.Conversation, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.CreateConversation", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.CreateConversation.GetUserFromCtx"))
+	}
+
+	seen := map[uuid.UUID]struct{}{self.UserID: {}}
+	recipients := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		recipients = append(recipients, userID)
+	}
+
+	if len(recipients) == 0 {
+		err = errors.New("conversation needs at least one other user")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "messageUC.CreateConversation"))
+	}
+	if len(recipients)+1 > u.cfg.Message.MaxMembers {
+		err = errors.Errorf("conversation can have at most %d members", u.cfg.Message.MaxMembers)
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "messageUC.CreateConversation"))
+	}
+
+	for _, recipient := range recipients {
+		if err = u.checkCanMessage(ctx, self.UserID, recipient); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+	}
+
+	isGroup := len(recipients) > 1 || name != nil
+	if !isGroup {
+		conversationID, err := u.messageRepo.GetDirectConversationID(ctx, self.UserID, recipients[0])
+		if err == nil {
+			return u.GetConversation(ctx, conversationID)
+		}
+		if errors.Cause(err) != sql.ErrNoRows {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+	}
+
+	conversation := &models.Conversation{CreatedBy: &self.UserID, IsGroup: isGroup, Name: name}
+	if err = utils.ValidateStruct(ctx, conversation); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "messageUC.CreateConversation.ValidateStruct"))
+	}
+
+	createdConversation, err := u.messageRepo.CreateConversation(ctx, conversation, append([]uuid.UUID{self.UserID}, recipients...))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.GetConversation(ctx, createdConversation.ID)
+}
+
+// Get conversation of current user with its members
+func (u *messageUC) GetConversation(ctx context.Context, conversationID uint64) (*models.Conversation, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.GetConversation", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.GetConversation.GetUserFromCtx"))
+	}
+
+	conversation, err := u.messageRepo.GetConversation(ctx, self.UserID, conversationID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if err = u.attachMembers(ctx, conversation); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+// Get conversations of current user, most recently active first
+func (u *messageUC) GetConversations(ctx context.Context, pq *utils.PaginationQuery) (*models.ConversationsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.GetConversations", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.GetConversations.GetUserFromCtx"))
+	}
+
+	conversationsList, err := u.messageRepo.GetConversations(ctx, self.UserID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if err = u.attachMembers(ctx, conversationsList.Conversations...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return conversationsList, nil
+}
+
+// Send message to a conversation of current user
+func (u *messageUC) CreateMessage(ctx context.Context, conversationID uint64, message *models.Message) (*models.Message, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.CreateMessage", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.CreateMessage.GetUserFromCtx"))
+	}
+
+	conversation, err := u.GetConversation(ctx, conversationID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	// Blocks made after a direct conversation started stop it, group conversations are left to their members
+	if !conversation.IsGroup {
+		for _, member := range conversation.Members {
+			if member.UserID == self.UserID {
+				continue
+			}
+			if err = u.checkNotBlocked(ctx, self.UserID, member.UserID); err != nil {
+				tracer.AddSpanError(span, err)
+				return nil, err
+			}
+		}
+	}
+
+	if (message.Text == nil || *message.Text == "") && message.Image == nil {
+		err = errors.New("message needs text or image")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "messageUC.CreateMessage"))
+	}
+
+	message.ConversationID = conversationID
+	message.UserID = self.UserID
+	if err = utils.ValidateStruct(ctx, message); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "messageUC.CreateMessage.ValidateStruct"))
+	}
+
+	createdMessage, err := u.messageRepo.CreateMessage(ctx, message)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	memberIDs := make([]uuid.UUID, 0, len(conversation.Members))
+	for _, member := range conversation.Members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	if err = u.streamUC.Publish(ctx, models.EventMessage, createdMessage, memberIDs...); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("messageUC.CreateMessage.Publish: %v", err)
+	}
+
+	return createdMessage, nil
+}
+
+// Get messages of a conversation of current user, newest first
+func (u *messageUC) GetMessages(ctx context.Context, conversationID uint64, pq *utils.PaginationQuery) (*models.MessagesList, error) {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.GetMessages", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.GetMessages.GetUserFromCtx"))
+	}
+
+	if _, err = u.messageRepo.GetConversation(ctx, self.UserID, conversationID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return u.messageRepo.GetMessages(ctx, conversationID, pq)
+}
+
+// Mark conversation of current user as read
+func (u *messageUC) MarkRead(ctx context.Context, conversationID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.MarkRead", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "messageUC.MarkRead.GetUserFromCtx"))
+	}
+
+	return u.messageRepo.MarkRead(ctx, conversationID, self.UserID)
+}
+
+// Users can be messaged by the people they follow, or by anyone when they allow it, unless either blocked the other
+func (u *messageUC) checkCanMessage(ctx context.Context, sender uuid.UUID, recipient uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "messageUC.checkCanMessage", nil)
+	defer span.End()
+
+	if err := u.checkNotBlocked(ctx, sender, recipient); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	allowDMs, err := u.messageRepo.CheckAllowDMs(ctx, recipient)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+	if allowDMs {
+		return nil
+	}
+
+	isFollowing, err := u.followRepo.IsFollowing(ctx, recipient, sender)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+	if !isFollowing {
+		return httpErrors.NewForbiddenError(errors.Errorf("messageUC.checkCanMessage: %s does not accept messages from %s", recipient, sender))
+	}
+
+	return nil
+}
+
+func (u *messageUC) checkNotBlocked(ctx context.Context, sender uuid.UUID, recipient uuid.UUID) error {
+	isBlocked, err := u.blockRepo.IsBlocked(ctx, sender, recipient)
+	if err != nil {
+		return err
+	}
+	if isBlocked {
+		return httpErrors.NewForbiddenError(errors.Errorf("messageUC.checkNotBlocked: messages between %s and %s are blocked", sender, recipient))
+	}
+	return nil
+}
+
+func (u *messageUC) attachMembers(ctx context.Context, conversations ...*models.Conversation) error {
+	conversationIDs := make([]uint64, 0, len(conversations))
+	byID := make(map[uint64]*models.Conversation, len(conversations))
+	for _, c := range conversations {
+		c.Members = make([]*models.ConversationMember, 0)
+		conversationIDs = append(conversationIDs, c.ID)
+		byID[c.ID] = c
+	}
+
+	members, err := u.messageRepo.GetMembers(ctx, conversationIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		if c, ok := byID[m.ConversationID]; ok {
+			c.Members = append(c.Members, m)
+		}
+	}
+	return nil
+}
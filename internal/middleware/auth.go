@@ -2,29 +2,29 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
-	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
 	"github.com/JamesHsu333/go-twitter/internal/models"
-	"github.com/JamesHsu333/go-twitter/internal/user"
 	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
 	"github.com/JamesHsu333/go-twitter/pkg/tracer"
 	"github.com/JamesHsu333/go-twitter/pkg/utils"
-	"github.com/dgrijalva/jwt-go"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/zap"
 )
 
-// Auth sessions middleware using redis
+// Auth sessions middleware using redis, or bearer access tokens in token auth mode
 func (mw *MiddlewareManager) AuthSessionMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracer.NewSpan(c.Request().Context(), "MiddlewareManager.AuthSessionMiddleware", nil)
 		defer span.End()
 
+		// Bearer access tokens stand in for the session cookie in token auth mode
+		if mw.cfg.Auth.Mode == auth.TokenMode && utils.ExtractBearerToken(c.Request()) != "" {
+			return mw.AuthJWTMiddleware(next)(c)
+		}
+
 		cookie, err := c.Cookie(mw.cfg.Session.Name)
 		if err != nil {
 			mw.logger.Errorf("AuthSessionMiddleware RequestID: %s, Error: %s",
@@ -79,46 +79,48 @@ func (mw *MiddlewareManager) AuthSessionMiddleware(next echo.HandlerFunc) echo.H
 	}
 }
 
-// JWT way of auth using cookie or Authorization header
-func (mw *MiddlewareManager) AuthJWTMiddleware(userUC user.UseCase, cfg *config.Config) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			span := tracer.SpanFromContext(c.Request().Context())
-			defer span.End()
-
-			bearerHeader := c.Request().Header.Get("Authorization")
-
-			mw.logger.Infof("auth middleware bearerHeader %s", bearerHeader)
-
-			if bearerHeader != "" {
-				headerParts := strings.Split(bearerHeader, " ")
-				if len(headerParts) != 2 {
-					mw.logger.Error("auth middleware", zap.String("headerParts", "len(headerParts) != 2"))
-					return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
-				}
-
-				tokenString := headerParts[1]
+// Bearer access token auth, for clients not keeping cookies in token auth mode
+func (mw *MiddlewareManager) AuthJWTMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(c.Request().Context(), "MiddlewareManager.AuthJWTMiddleware", nil)
+		defer span.End()
 
-				if err := mw.validateJWTToken(tokenString, userUC, c, cfg); err != nil {
-					mw.logger.Error("middleware validateJWTToken", zap.String("headerJWT", err.Error()))
-					return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
-				}
+		claims, err := mw.authUC.ValidateAccessToken(ctx, utils.ExtractBearerToken(c.Request()))
+		if err != nil {
+			mw.logger.Errorf("ValidateAccessToken RequestID: %s, Error: %s",
+				utils.GetRequestID(c),
+				err.Error(),
+			)
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.InvalidJWTToken))
+		}
 
-				return next(c)
-			}
+		userID, err := uuid.Parse(claims.ID)
+		if err != nil {
+			mw.logger.Errorf("AuthJWTMiddleware RequestID: %s, Error: %s",
+				utils.GetRequestID(c),
+				err.Error(),
+			)
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.InvalidJWTClaims))
+		}
 
-			cookie, err := c.Cookie("jwt-token")
+		user, err := mw.userUC.GetCacheByID(ctx, userID, userID)
+		if err != nil {
+			user, err = mw.userUC.GetByID(ctx, userID, userID)
 			if err != nil {
-				mw.logger.Errorf("c.Cookie", err.Error())
-				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
-			}
-
-			if err = mw.validateJWTToken(cookie.Value, userUC, c, cfg); err != nil {
-				mw.logger.Errorf("validateJWTToken", err.Error())
+				mw.logger.Errorf("GetByID RequestID: %s, Error: %s",
+					utils.GetRequestID(c),
+					err.Error(),
+				)
 				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
 			}
-			return next(c)
 		}
+
+		c.Set("claims", claims)
+		c.Set("user", user)
+
+		c.SetRequest(c.Request().WithContext(context.WithValue(ctx, utils.UserCtxKey{}, user)))
+
+		return next(c)
 	}
 }
 
@@ -138,29 +140,7 @@ func (mw *MiddlewareManager) AdminMiddleware(next echo.HandlerFunc) echo.Handler
 
 // Check owner using ctx user
 func (mw *MiddlewareManager) OwnerMiddleware() echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			span := tracer.SpanFromContext(c.Request().Context())
-			defer span.End()
-
-			user, ok := c.Get("user").(*models.User)
-			if !ok {
-				mw.logger.Errorf("Error c.Get(user) RequestID: %s, ERROR: %s,", utils.GetRequestID(c), "invalid user ctx")
-				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
-			}
-
-			if user.UserID.String() != c.Param("user_id") {
-				mw.logger.Errorf("Error c.Get(user) RequestID: %s, UserID: %s, ERROR: %s,",
-					utils.GetRequestID(c),
-					user.UserID.String(),
-					"invalid user ctx",
-				)
-				return c.JSON(http.StatusForbidden, httpErrors.NewForbiddenError(httpErrors.Forbidden))
-			}
-
-			return next(c)
-		}
-	}
+	return mw.ResourceOwnerMiddleware(ParamOwner("user_id"))
 }
 
 // Role based auth middleware, using ctx user
@@ -197,54 +177,6 @@ func (mw *MiddlewareManager) RoleBasedAuthMiddleware(roles []string) echo.Middle
 	}
 }
 
-func (mw *MiddlewareManager) validateJWTToken(tokenString string, userUC user.UseCase, c echo.Context, cfg *config.Config) error {
-	ctx, span := tracer.NewSpan(c.Request().Context(), "MiddlewareManager.validateJWTToken", nil)
-	defer span.End()
-
-	if tokenString == "" {
-		return httpErrors.InvalidJWTToken
-	}
-
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
-		}
-		secret := []byte(cfg.Server.JwtSecretKey)
-		return secret, nil
-	})
-	if err != nil {
-		return err
-	}
-
-	if !token.Valid {
-		return httpErrors.InvalidJWTToken
-	}
-
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["id"].(string)
-		if !ok {
-			return httpErrors.InvalidJWTClaims
-		}
-
-		userUUID, err := uuid.Parse(userID)
-		if err != nil {
-			return err
-		}
-
-		u, err := userUC.GetByID(c.Request().Context(), userUUID, userUUID)
-		if err != nil {
-			return err
-		}
-
-		c.Set("user", u)
-
-		ctx := context.WithValue(ctx, utils.UserCtxKey{}, u)
-		// req := c.Request().WithContext(ctx)
-		c.SetRequest(c.Request().WithContext(ctx))
-	}
-	return nil
-}
-
 // Check auth middleware
 func (mw *MiddlewareManager) CheckAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
@@ -16,6 +16,11 @@ func (mw *MiddlewareManager) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
 			return next(ctx)
 		}
 
+		// Browsers do not send bearer tokens on their own, requests authenticated by one cannot be forged
+		if _, ok := ctx.Get("claims").(*utils.Claims); ok {
+			return next(ctx)
+		}
+
 		token := ctx.Request().Header.Get(csrf.CSRFHeader)
 		if token == "" {
 			mw.logger.Errorf("CSRF Middleware get CSRF header, Token: %s, Error: %s, RequestId: %s",
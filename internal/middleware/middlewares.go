@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
 	"github.com/JamesHsu333/go-twitter/internal/session"
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
 	"github.com/JamesHsu333/go-twitter/internal/user"
 	"github.com/JamesHsu333/go-twitter/pkg/logger"
 )
@@ -10,13 +12,15 @@ import (
 // Middleware manager
 type MiddlewareManager struct {
 	sessUC  session.UCSession
+	authUC  auth.UseCase
 	userUC  user.UseCase
+	tweetUC tweet.UseCase
 	cfg     *config.Config
 	origins []string
 	logger  logger.Logger
 }
 
 // Middleware manager constructor
-func NewMiddlewareManager(sessUC session.UCSession, userUC user.UseCase, cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
-	return &MiddlewareManager{sessUC: sessUC, userUC: userUC, cfg: cfg, origins: origins, logger: logger}
+func NewMiddlewareManager(sessUC session.UCSession, authUC auth.UseCase, userUC user.UseCase, tweetUC tweet.UseCase, cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
+	return &MiddlewareManager{sessUC: sessUC, authUC: authUC, userUC: userUC, tweetUC: tweetUC, cfg: cfg, origins: origins, logger: logger}
 }
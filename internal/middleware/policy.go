@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Resource owner loader, returns the owner id of the resource addressed by the request
+type OwnerLoader func(c echo.Context) (uuid.UUID, error)
+
+// Owner is the user id in the given path param.
+// Malformed ids match no user, so they are forbidden rather than bad requests.
+func ParamOwner(param string) OwnerLoader {
+	return func(c echo.Context) (uuid.UUID, error) {
+		ownerID, err := uuid.Parse(c.Param(param))
+		if err != nil {
+			return uuid.Nil, nil
+		}
+		return ownerID, nil
+	}
+}
+
+// Check ctx user owns the resource or has one of the given roles
+func (mw *MiddlewareManager) ResourceOwnerMiddleware(loadOwner OwnerLoader, roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			span := tracer.SpanFromContext(c.Request().Context())
+			defer span.End()
+
+			user, ok := c.Get("user").(*models.User)
+			if !ok {
+				mw.logger.Errorf("Error c.Get(user) RequestID: %s, ERROR: %s,", utils.GetRequestID(c), "invalid user ctx")
+				return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+			}
+
+			if user.Role != nil {
+				for _, role := range roles {
+					if role == *user.Role {
+						return next(c)
+					}
+				}
+			}
+
+			ownerID, err := loadOwner(c)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, mw.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+
+			if user.UserID != ownerID {
+				mw.logger.Errorf("Error ResourceOwnerMiddleware RequestID: %s, UserID: %s, OwnerID: %s, ERROR: %s,",
+					utils.GetRequestID(c),
+					user.UserID.String(),
+					ownerID.String(),
+					"not resource owner",
+				)
+				return c.JSON(http.StatusForbidden, httpErrors.NewForbiddenError(httpErrors.Forbidden))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Check tweet author or admin using ctx user
+func (mw *MiddlewareManager) TweetOwnerMiddleware() echo.MiddlewareFunc {
+	return mw.ResourceOwnerMiddleware(mw.TweetOwner, "admin")
+}
+
+// Tweet author of the tweet_id path param
+func (mw *MiddlewareManager) TweetOwner(c echo.Context) (uuid.UUID, error) {
+	tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+	if err != nil {
+		return uuid.Nil, httpErrors.NewBadRequestError(err)
+	}
+
+	// Admins may act on tweets hidden from them, so the owner is looked up without visibility rules
+	tweet, err := mw.tweetUC.GetStoredTweet(c.Request().Context(), tweetID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return tweet.UserID, nil
+}
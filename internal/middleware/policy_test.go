@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/tweet/mock"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+func newTestMiddlewareManager(t *testing.T) (*MiddlewareManager, *mock.MockUseCase) {
+	t.Helper()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{Mode: "Development"},
+		Logger: config.Logger{Level: "fatal"},
+	}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+
+	tweetUC := mock.NewMockUseCase(gomock.NewController(t))
+	return NewMiddlewareManager(nil, nil, nil, tweetUC, cfg, nil, apiLogger), tweetUC
+}
+
+func serveOwnerMiddleware(mw echo.MiddlewareFunc, user *models.User, param string, value string) int {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(param)
+	c.SetParamValues(value)
+	c.Set("user", user)
+
+	next := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+	if err := mw(next)(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	return rec.Code
+}
+
+func TestTweetOwnerMiddleware(t *testing.T) {
+	authorID := uuid.New()
+	admin := "admin"
+
+	tests := []struct {
+		name   string
+		user   *models.User
+		tweet  *models.Tweet
+		err    error
+		lookup bool
+		status int
+	}{
+		{
+			name:   "non-owner is forbidden",
+			user:   &models.User{UserID: uuid.New()},
+			tweet:  &models.Tweet{ID: 1, UserID: authorID},
+			lookup: true,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "author passes",
+			user:   &models.User{UserID: authorID},
+			tweet:  &models.Tweet{ID: 1, UserID: authorID},
+			lookup: true,
+			status: http.StatusOK,
+		},
+		{
+			name:   "admin passes without owner lookup",
+			user:   &models.User{UserID: uuid.New(), Role: &admin},
+			status: http.StatusOK,
+		},
+		{
+			name:   "unknown tweet is not found",
+			user:   &models.User{UserID: uuid.New()},
+			err:    errors.Wrap(sql.ErrNoRows, "tweetRepo.GetStoredTweet.GetContext"),
+			lookup: true,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, tweetUC := newTestMiddlewareManager(t)
+			if tt.lookup {
+				tweetUC.EXPECT().GetStoredTweet(gomock.Any(), uint64(1)).Return(tt.tweet, tt.err)
+			}
+
+			if status := serveOwnerMiddleware(mw.TweetOwnerMiddleware(), tt.user, "tweet_id", "1"); status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestTweetAuthorOnly(t *testing.T) {
+	authorID := uuid.New()
+	admin := "admin"
+
+	tests := []struct {
+		name   string
+		user   *models.User
+		status int
+	}{
+		{name: "author passes", user: &models.User{UserID: authorID}, status: http.StatusOK},
+		{name: "admin is forbidden", user: &models.User{UserID: uuid.New(), Role: &admin}, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, tweetUC := newTestMiddlewareManager(t)
+			tweetUC.EXPECT().GetStoredTweet(gomock.Any(), uint64(1)).Return(&models.Tweet{ID: 1, UserID: authorID}, nil)
+
+			if status := serveOwnerMiddleware(mw.ResourceOwnerMiddleware(mw.TweetOwner), tt.user, "tweet_id", "1"); status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestOwnerMiddleware(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		param  string
+		status int
+	}{
+		{name: "owner passes", param: userID.String(), status: http.StatusOK},
+		{name: "other user is forbidden", param: uuid.New().String(), status: http.StatusForbidden},
+		{name: "malformed user id is forbidden", param: "not-a-uuid", status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw, _ := newTestMiddlewareManager(t)
+
+			if status := serveOwnerMiddleware(mw.OwnerMiddleware(), &models.User{UserID: userID}, "user_id", tt.param); status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark folder, bookmarks without a folder stay in the default collection
+type BookmarkFolder struct {
+	ID        uint64    `json:"id" db:"id" redis:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id" redis:"user_id"`
+	Name      string    `json:"name" db:"name" redis:"name" validate:"required,lte=64"`
+	Bookmarks int64     `json:"bookmarks" db:"bookmarks" redis:"bookmarks"`
+	CreatedAt time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+}
+
+// Bookmark folders response
+type BookmarkFoldersList struct {
+	Folders []*BookmarkFolder `json:"folders"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Draft statuses
+const (
+	DraftPending    = "pending"
+	DraftPublishing = "publishing"
+	DraftPublished  = "published"
+	DraftFailed     = "failed"
+	// Publishing was interrupted, the tweet may or may not have been created
+	DraftUnknown = "unknown"
+)
+
+// Tweet draft saved server side, scheduled for publishing when publish_at is set
+type Draft struct {
+	ID        uint64     `json:"id" db:"id" redis:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id" redis:"user_id"`
+	Text      string     `json:"text" form:"text" db:"text" redis:"text" validate:"required,lte=260"`
+	Image     *string    `json:"image,omitempty" db:"image" redis:"image" validate:"omitempty,lte=512"`
+	PublishAt *time.Time `json:"publish_at,omitempty" form:"publish_at" db:"publish_at" redis:"publish_at"`
+	Status    string     `json:"status" db:"status" redis:"status"`
+	TweetID   *uint64    `json:"tweet_id,omitempty" db:"tweet_id" redis:"tweet_id"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at" redis:"updated_at"`
+}
+
+// All Drafts response
+type DraftsList struct {
+	TotalCount int      `json:"total_count"`
+	TotalPages int      `json:"total_pages"`
+	Page       int      `json:"page"`
+	Size       int      `json:"size"`
+	HasMore    bool     `json:"has_more"`
+	Drafts     []*Draft `json:"drafts"`
+}
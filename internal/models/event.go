@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// Stream event types
+const (
+	EventTweet        = "tweet"
+	EventLike         = "like"
+	EventFollow       = "follow"
+	EventNotification = "notification"
+	EventMessage      = "message"
+)
+
+// Real-time event pushed to a user's stream
+type Event struct {
+	ID     string          `json:"id" redis:"id"`
+	UserID uuid.UUID       `json:"user_id" redis:"user_id"`
+	Type   string          `json:"type" redis:"type"`
+	Data   json.RawMessage `json:"data" redis:"data"`
+}
+
+// Like count change of a tweet
+type LikeEvent struct {
+	TweetID uint64    `json:"tweet_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	Likes   int64     `json:"likes"`
+	Liked   bool      `json:"liked"`
+}
+
+// New follower of a user
+type FollowEvent struct {
+	FollowerID uuid.UUID `json:"follower_id"`
+}
@@ -1,10 +1,57 @@
 package models
 
-import "io"
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type UploadInput struct {
 	File        io.Reader
 	Name        string
 	Size        int64
 	ContentType string
+	// Object key, generated from Name when empty
+	Key string
+}
+
+// Image stored as a fixed set of renditions, url is the largest one
+type Image struct {
+	URL        string            `json:"url"`
+	Width      int               `json:"width"`
+	Height     int               `json:"height"`
+	BlurHash   string            `json:"blurhash"`
+	Renditions map[string]string `json:"renditions"`
+}
+
+// Object found in storage
+type StoredObject struct {
+	Key          string
+	URL          string
+	Size         int64
+	LastModified time.Time
+}
+
+// Stored image accounted to the user who uploaded it, checksum is of the uploaded content
+type MediaObject struct {
+	ID          uint64    `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	URL         string    `json:"url" db:"url"`
+	Size        int64     `json:"size" db:"size"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Checksum    string    `json:"checksum" db:"checksum"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	BlurHash    string    `json:"blurhash" db:"blurhash"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Storage used by user, quota is omitted when unlimited
+type StorageUsage struct {
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	UsedBytes      int64     `json:"used_bytes" db:"used_bytes"`
+	Objects        int64     `json:"objects" db:"objects"`
+	QuotaBytes     *int64    `json:"quota_bytes,omitempty" db:"-"`
+	RemainingBytes *int64    `json:"remaining_bytes,omitempty" db:"-"`
 }
@@ -0,0 +1,13 @@
+package models
+
+// Trending hashtag
+type Trend struct {
+	Hashtag    string  `json:"hashtag" redis:"hashtag"`
+	TweetCount int64   `json:"tweet_count" redis:"tweet_count"`
+	Score      float64 `json:"score" redis:"score"`
+}
+
+// Trends response
+type TrendsList struct {
+	Trends []*Trend `json:"trends"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Curated list of users, private lists are only visible to their owner
+type List struct {
+	ID              uint64    `json:"id" db:"id" redis:"id"`
+	OwnerID         uuid.UUID `json:"owner_id" db:"owner_id" redis:"owner_id"`
+	Name            string    `json:"name" db:"name" redis:"name" validate:"required,lte=64"`
+	Description     *string   `json:"description,omitempty" db:"description" redis:"description" validate:"omitempty,lte=256"`
+	IsPrivate       *bool     `json:"is_private" db:"is_private" redis:"is_private"`
+	MemberCount     int64     `json:"member_count" db:"member_count" redis:"member_count"`
+	SubscriberCount int64     `json:"subscriber_count" db:"subscriber_count" redis:"subscriber_count"`
+	IsSubscribed    bool      `json:"is_subscribed" db:"is_subscribed" redis:"is_subscribed"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at" redis:"updated_at"`
+}
+
+// All Lists response
+type ListsList struct {
+	TotalCount int     `json:"total_count"`
+	TotalPages int     `json:"total_pages"`
+	Page       int     `json:"page"`
+	Size       int     `json:"size"`
+	HasMore    bool    `json:"has_more"`
+	Lists      []*List `json:"lists"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Image attached to a tweet, url, width and height are of its largest rendition
+type Media struct {
+	ID       uint64    `json:"media_id" db:"id" redis:"id"`
+	UserID   uuid.UUID `json:"-" db:"user_id" redis:"user_id"`
+	TweetID  *uint64   `json:"tweet_id,omitempty" db:"tweet_id" redis:"tweet_id"`
+	Position *int      `json:"position,omitempty" db:"position" redis:"position"`
+	URL      string    `json:"url" db:"url" redis:"url"`
+	Width    *int      `json:"width,omitempty" db:"width" redis:"width"`
+	Height   *int      `json:"height,omitempty" db:"height" redis:"height"`
+	BlurHash *string   `json:"blurhash,omitempty" db:"blurhash" redis:"blurhash"`
+	// Urls of renditions by name, derived from url
+	Renditions map[string]string `json:"renditions,omitempty" db:"-" redis:"-"`
+	AltText    *string           `json:"alt_text,omitempty" form:"alt_text" db:"alt_text" redis:"alt_text" validate:"omitempty,lte=1000"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at" redis:"created_at"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conversation model, direct when it is not a group
+type Conversation struct {
+	ID              uint64                `json:"id" db:"id" redis:"id"`
+	CreatedBy       *uuid.UUID            `json:"created_by,omitempty" db:"created_by" redis:"created_by"`
+	IsGroup         bool                  `json:"is_group" db:"is_group" redis:"is_group"`
+	Name            *string               `json:"name,omitempty" db:"name" redis:"name" validate:"omitempty,lte=64"`
+	LastMessageID   *uint64               `json:"last_message_id,omitempty" db:"last_message_id" redis:"last_message_id"`
+	LastMessageText *string               `json:"last_message_text,omitempty" db:"last_message_text" redis:"last_message_text"`
+	LastMessageAt   *time.Time            `json:"last_message_at,omitempty" db:"last_message_at" redis:"last_message_at"`
+	UnreadCount     int64                 `json:"unread_count" db:"unread_count" redis:"unread_count"`
+	Members         []*ConversationMember `json:"members" db:"-" redis:"-"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at" db:"updated_at" redis:"updated_at"`
+}
+
+// Conversation member with read receipt
+type ConversationMember struct {
+	ConversationID    uint64    `json:"-" db:"conversation_id" redis:"conversation_id"`
+	UserID            uuid.UUID `json:"user_id" db:"user_id" redis:"user_id"`
+	UserName          string    `json:"user_name" db:"user_name" redis:"user_name"`
+	Name              string    `json:"name" db:"name" redis:"name"`
+	Avatar            *string   `json:"avatar,omitempty" db:"avatar" redis:"avatar"`
+	LastReadMessageID uint64    `json:"last_read_message_id" db:"last_read_message_id" redis:"last_read_message_id"`
+	JoinedAt          time.Time `json:"joined_at" db:"joined_at" redis:"joined_at"`
+}
+
+// Direct message
+type Message struct {
+	ID             uint64    `json:"id" db:"id" redis:"id"`
+	ConversationID uint64    `json:"conversation_id" db:"conversation_id" redis:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id" redis:"user_id"`
+	Text           *string   `json:"text,omitempty" form:"text" db:"text" redis:"text" validate:"omitempty,lte=1000"`
+	Image          *string   `json:"image,omitempty" db:"image" redis:"image" validate:"omitempty,lte=512"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+}
+
+// All Conversations response
+type ConversationsList struct {
+	TotalCount    int             `json:"total_count"`
+	TotalPages    int             `json:"total_pages"`
+	Page          int             `json:"page"`
+	Size          int             `json:"size"`
+	HasMore       bool            `json:"has_more"`
+	Conversations []*Conversation `json:"conversations"`
+}
+
+// All Messages response
+type MessagesList struct {
+	TotalCount int        `json:"total_count"`
+	TotalPages int        `json:"total_pages"`
+	Page       int        `json:"page"`
+	Size       int        `json:"size"`
+	HasMore    bool       `json:"has_more"`
+	Messages   []*Message `json:"messages"`
+}
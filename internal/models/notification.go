@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types
+const (
+	NotificationLike          = "like"
+	NotificationFollow        = "follow"
+	NotificationFollowRequest = "follow_request"
+	NotificationFollowAccept  = "follow_accept"
+	NotificationReply         = "reply"
+	NotificationMention       = "mention"
+)
+
+// Notification model, grouping every actor of the same event on the same tweet
+type Notification struct {
+	ID            uint64     `json:"id" db:"id" redis:"id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id" redis:"user_id"`
+	Type          string     `json:"type" db:"type" redis:"type"`
+	TweetID       *uint64    `json:"tweet_id,omitempty" db:"tweet_id" redis:"tweet_id"`
+	TweetText     *string    `json:"tweet_text,omitempty" db:"tweet_text" redis:"tweet_text"`
+	ActorID       uuid.UUID  `json:"actor_id" db:"actor_id" redis:"actor_id"`
+	ActorUserName string     `json:"actor_user_name" db:"actor_user_name" redis:"actor_user_name"`
+	ActorName     string     `json:"actor_name" db:"actor_name" redis:"actor_name"`
+	ActorAvatar   *string    `json:"actor_avatar,omitempty" db:"actor_avatar" redis:"actor_avatar"`
+	ActorsCount   int64      `json:"actors_count" db:"actors_count" redis:"actors_count"`
+	Message       string     `json:"message" db:"-" redis:"message"`
+	ReadAt        *time.Time `json:"read_at,omitempty" db:"read_at" redis:"read_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at" redis:"updated_at"`
+}
+
+// All Notifications response
+type NotificationsList struct {
+	TotalCount    int             `json:"total_count"`
+	TotalPages    int             `json:"total_pages"`
+	Page          int             `json:"page"`
+	Size          int             `json:"size"`
+	HasMore       bool            `json:"has_more"`
+	Notifications []*Notification `json:"notifications"`
+}
+
+// Unread notifications count response
+type UnreadCount struct {
+	Count int `json:"count"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refresh token statuses
+const (
+	RefreshTokenActive  = "active"
+	RefreshTokenRotated = "rotated"
+	RefreshTokenRevoked = "revoked"
+)
+
+// Refresh token, only its hash is stored.
+// Tokens rotated from the same login share a family, which is revoked at once.
+type RefreshToken struct {
+	ID        uuid.UUID `json:"id" db:"id" redis:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id" redis:"user_id"`
+	FamilyID  uuid.UUID `json:"family_id" db:"family_id" redis:"family_id"`
+	TokenHash string    `json:"token_hash" db:"token_hash" redis:"token_hash"`
+	Status    string    `json:"status" db:"status" redis:"status"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at" redis:"expires_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at" redis:"updated_at"`
+}
+
+// Access and refresh tokens issued to bearer clients
+type AuthTokens struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+// Refresh or revoke request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,lte=100"`
+}
@@ -8,28 +8,108 @@ import (
 
 // Tweet Model
 type Tweet struct {
-	ID        uint64    `json:"id" form:"id" db:"id" redis:"id" validate:"omitempty"`
-	UserID    uuid.UUID `json:"user_id" form:"user_id" db:"user_id" redis:"user_id" validate:"omitempty,required"`
-	Text      string    `json:"text" form:"text" db:"text" redis:"text" validate:"omitempty,required,lte=260"`
-	Image     *string   `json:"image,omitempty" form:"image" db:"image" redis:"image" validate:"omitempty,lte=512"`
-	Likes     int64     `json:"likes" form:"likes" db:"likes" redis:"likes" validate:"omitempty"`
-	Replys    int64     `json:"replys" form:"replys" db:"replys" redis:"replys" validate:"omitempty"`
-	CreatedAt time.Time `json:"created_at,omitempty" form:"created_at" db:"created_at" redis:"created_at"`
+	ID             uint64     `json:"id" form:"id" db:"id" redis:"id" validate:"omitempty"`
+	UserID         uuid.UUID  `json:"user_id" form:"user_id" db:"user_id" redis:"user_id" validate:"omitempty,required"`
+	Text           string     `json:"text" form:"text" db:"text" redis:"text" validate:"omitempty,required,lte=260"`
+	Image          *string    `json:"image,omitempty" form:"image" db:"image" redis:"image" validate:"omitempty,lte=512"`
+	Likes          int64      `json:"likes" form:"likes" db:"likes" redis:"likes" validate:"omitempty"`
+	Replys         int64      `json:"replys" form:"replys" db:"replys" redis:"replys" validate:"omitempty"`
+	QuoteID        *uint64    `json:"quote_id,omitempty" db:"quote_id" redis:"quote_id"`
+	InReplyToID    *uint64    `json:"in_reply_to_id,omitempty" db:"in_reply_to_id" redis:"in_reply_to_id"`
+	ConversationID *uint64    `json:"conversation_id,omitempty" db:"conversation_id" redis:"conversation_id"`
+	Mentions       []*Mention `json:"mentions,omitempty" db:"-" redis:"-"`
+	PollOptions    []string   `json:"poll_options,omitempty" form:"poll_options" db:"-" redis:"-" validate:"omitempty,min=2,max=4,dive,required,lte=25"`
+	PollDuration   int        `json:"poll_duration_minutes,omitempty" form:"poll_duration_minutes" db:"-" redis:"-" validate:"omitempty,gte=5,lte=10080"`
+	Poll           *Poll      `json:"poll,omitempty" db:"-" redis:"-"`
+	MediaIDs       []uint64   `json:"media_ids,omitempty" form:"media_ids" db:"-" redis:"-" validate:"omitempty,max=4"`
+	Media          []*Media   `json:"media,omitempty" db:"-" redis:"-"`
+	EditedAt       *time.Time `json:"edited_at,omitempty" db:"edited_at" redis:"edited_at"`
+	EditCount      int        `json:"edit_count" db:"edit_count" redis:"edit_count"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" form:"created_at" db:"created_at" redis:"created_at"`
 }
 
 type TweetWithUser struct {
-	ID           uint64    `json:"id" db:"id" redis:"id" validate:"omitempty"`
-	Text         string    `json:"text" db:"text" redis:"text" validate:"omitempty,required,lte=260"`
-	Image        *string   `json:"image,omitempty" db:"image" redis:"image" validate:"omitempty,lte=512,url"`
-	CreatedAt    time.Time `json:"created_at,omitempty" db:"created_at" redis:"created_at"`
-	UserID       uuid.UUID `json:"user_id" db:"user_id" redis:"user_id" validate:"omitempty"`
-	UserName     string    `json:"user_name" db:"user_name" redis:"user_name" validate:"omitempty,required,lte=32"`
-	Name         string    `json:"name" db:"name" redis:"name" validate:"omitempty,required,lte=32"`
-	About        *string   `json:"about,omitempty" db:"about" redis:"about" validate:"omitempty,lte=160"`
-	Avatar       *string   `json:"avatar,omitempty" db:"avatar" redis:"avatar" validate:"omitempty,lte=512,url"`
-	Likes        int64     `json:"likes" db:"likes" redis:"likes" validate:"omitempty"`
-	Replys       int64     `json:"replys" db:"replys" redis:"replys" validate:"omitempty"`
-	AlreadyLiked bool      `json:"already_liked" db:"already_liked" redis:"already_liked"`
+	ID                  uint64     `json:"id" db:"id" redis:"id" validate:"omitempty"`
+	Text                string     `json:"text" db:"text" redis:"text" validate:"omitempty,required,lte=260"`
+	Image               *string    `json:"image,omitempty" db:"image" redis:"image" validate:"omitempty,lte=512,url"`
+	CreatedAt           time.Time  `json:"created_at,omitempty" db:"created_at" redis:"created_at"`
+	EditedAt            *time.Time `json:"edited_at,omitempty" db:"edited_at" redis:"edited_at"`
+	EditCount           int        `json:"edit_count" db:"edit_count" redis:"edit_count"`
+	UserID              uuid.UUID  `json:"user_id" db:"user_id" redis:"user_id" validate:"omitempty"`
+	UserName            string     `json:"user_name" db:"user_name" redis:"user_name" validate:"omitempty,required,lte=32"`
+	Name                string     `json:"name" db:"name" redis:"name" validate:"omitempty,required,lte=32"`
+	About               *string    `json:"about,omitempty" db:"about" redis:"about" validate:"omitempty,lte=160"`
+	Avatar              *string    `json:"avatar,omitempty" db:"avatar" redis:"avatar" validate:"omitempty,lte=512,url"`
+	Likes               int64      `json:"likes" db:"likes" redis:"likes" validate:"omitempty"`
+	Replys              int64      `json:"replys" db:"replys" redis:"replys" validate:"omitempty"`
+	AlreadyLiked        bool       `json:"already_liked" db:"already_liked" redis:"already_liked"`
+	Retweets            int64      `json:"retweets" db:"retweets" redis:"retweets" validate:"omitempty"`
+	AlreadyRetweeted    bool       `json:"already_retweeted" db:"already_retweeted" redis:"already_retweeted"`
+	AlreadyBookmarked   bool       `json:"already_bookmarked" db:"already_bookmarked" redis:"already_bookmarked"`
+	QuoteID             *uint64    `json:"quote_id,omitempty" db:"quote_id" redis:"quote_id"`
+	InReplyToID         *uint64    `json:"in_reply_to_id,omitempty" db:"in_reply_to_id" redis:"in_reply_to_id"`
+	ConversationID      uint64     `json:"conversation_id" db:"conversation_id" redis:"conversation_id"`
+	Depth               *int       `json:"depth,omitempty" db:"depth" redis:"depth"`
+	RetweetedBy         *uuid.UUID `json:"retweeted_by,omitempty" db:"retweeted_by" redis:"retweeted_by"`
+	RetweetedByUserName *string    `json:"retweeted_by_user_name,omitempty" db:"retweeted_by_user_name" redis:"retweeted_by_user_name"`
+	RetweetedAt         *time.Time `json:"retweeted_at,omitempty" db:"retweeted_at" redis:"retweeted_at"`
+	Highlight           *string    `json:"highlight,omitempty" db:"highlight" redis:"highlight"`
+	Mentions            []*Mention `json:"mentions,omitempty" db:"-" redis:"-"`
+	Poll                *Poll      `json:"poll,omitempty" db:"-" redis:"-"`
+	Media               []*Media   `json:"media,omitempty" db:"-" redis:"-"`
+}
+
+// Mentioned user with character offsets of the mention in tweet text
+type Mention struct {
+	TweetID  uint64    `json:"-" db:"tweet_id" redis:"tweet_id"`
+	UserID   uuid.UUID `json:"user_id" db:"user_id" redis:"user_id"`
+	UserName string    `json:"user_name" db:"user_name" redis:"user_name"`
+	Start    int       `json:"start" db:"-" redis:"start"`
+	End      int       `json:"end" db:"-" redis:"end"`
+}
+
+// Poll attached to a tweet.
+// Vote counts are hidden from a user until they vote or the poll closes.
+type Poll struct {
+	EndsAt      time.Time     `json:"ends_at" redis:"ends_at"`
+	Closed      bool          `json:"closed" redis:"closed"`
+	VotedOption *int          `json:"voted_option,omitempty" redis:"voted_option"`
+	TotalVotes  *int64        `json:"total_votes,omitempty" redis:"total_votes"`
+	Options     []*PollOption `json:"options" redis:"options"`
+}
+
+// Poll option with its vote count and the vote of the requesting user
+type PollOption struct {
+	TweetID       uint64    `json:"-" db:"tweet_id" redis:"tweet_id"`
+	EndsAt        time.Time `json:"-" db:"ends_at" redis:"ends_at"`
+	Position      int       `json:"position" db:"position" redis:"position"`
+	Label         string    `json:"label" db:"label" redis:"label"`
+	Votes         *int64    `json:"votes,omitempty" db:"votes" redis:"votes"`
+	VotedPosition *int      `json:"-" db:"voted_position" redis:"voted_position"`
+}
+
+// Vote for a poll option, positions start at 1
+type PollVote struct {
+	Position int `json:"position" validate:"required,gte=1,lte=4"`
+}
+
+// Previous version of an edited tweet, created_at is when the version was published
+type TweetEdit struct {
+	ID        uint64    `json:"id" db:"id" redis:"id"`
+	TweetID   uint64    `json:"tweet_id" db:"tweet_id" redis:"tweet_id"`
+	Text      string    `json:"text" db:"text" redis:"text"`
+	Image     *string   `json:"image,omitempty" db:"image" redis:"image"`
+	CreatedAt time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+}
+
+// Parsed tweet search query
+type TweetSearch struct {
+	Text         string
+	FromUserName *string
+	HasImage     *bool
+	Since        *time.Time
+	Until        *time.Time
+	MinLikes     int64
 }
 
 // All Tweets response
@@ -39,5 +119,19 @@ type TweetsList struct {
 	Page       int              `json:"page"`
 	Size       int              `json:"size"`
 	HasMore    bool             `json:"has_more"`
+	NextCursor string           `json:"next_cursor,omitempty"`
 	Tweets     []*TweetWithUser `json:"tweets"`
 }
+
+// Edit history response, edits ordered from newest to oldest
+type TweetHistory struct {
+	Tweet *TweetWithUser `json:"tweet"`
+	Edits []*TweetEdit   `json:"edits"`
+}
+
+// Conversation thread response
+type TweetThread struct {
+	Ancestors []*TweetWithUser `json:"ancestors"`
+	Tweet     *TweetWithUser   `json:"tweet"`
+	Replies   *TweetsList      `json:"replies"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload purposes
+const (
+	UploadAvatar = "avatar"
+	UploadHeader = "header"
+	UploadMedia  = "media"
+)
+
+// Upload session statuses
+const (
+	UploadPending    = "pending"
+	UploadCompleting = "completing"
+	UploadCompleted  = "completed"
+	UploadFailed     = "failed"
+)
+
+// Upload of an object directly to storage, attached once completed
+type UploadSession struct {
+	ID          uuid.UUID `json:"upload_id" db:"id" redis:"id"`
+	UserID      uuid.UUID `json:"-" db:"user_id" redis:"user_id"`
+	Purpose     string    `json:"purpose" db:"purpose" redis:"purpose" validate:"required,oneof=avatar header media"`
+	ObjectKey   string    `json:"-" db:"object_key" redis:"object_key"`
+	ContentType string    `json:"content_type" db:"content_type" redis:"content_type" validate:"required,oneof=image/jpeg image/png"`
+	Status      string    `json:"status" db:"status" redis:"status"`
+	MediaID     *uint64   `json:"media_id,omitempty" db:"media_id" redis:"media_id"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at" redis:"expires_at"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at" redis:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at" redis:"updated_at"`
+	// Presigned url the object is uploaded to with a PUT, only returned on create
+	UploadURL string `json:"upload_url,omitempty" db:"-" redis:"-"`
+	// Attachment the object became on complete
+	User  *User  `json:"user,omitempty" db:"-" redis:"-"`
+	Media *Media `json:"media,omitempty" db:"-" redis:"-"`
+}
+
+// Complete upload request
+type UploadCompletion struct {
+	AltText *string `json:"alt_text,omitempty" validate:"omitempty,lte=1000"`
+}
@@ -26,6 +26,9 @@ type User struct {
 	Followers   *int64     `json:"followers" db:"followers" redis:"followers" validate:"omitempty"`
 	Following   *int64     `json:"following" db:"following" redis:"following" validate:"omitempty"`
 	IsFollowing *bool      `json:"is_following" db:"is_following" redis:"is_following" validate:"omitempty"`
+	AllowDMs    *bool      `json:"allow_dms,omitempty" db:"allow_dms" redis:"allow_dms" validate:"omitempty"`
+	IsPrivate   *bool      `json:"is_private,omitempty" db:"is_private" redis:"is_private" validate:"omitempty"`
+	IsRequested *bool      `json:"is_requested,omitempty" db:"is_requested" redis:"is_requested" validate:"omitempty"`
 	CreatedAt   time.Time  `json:"created_at,omitempty" db:"created_at" redis:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at,omitempty" db:"updated_at" redis:"updated_at"`
 	LoginDate   time.Time  `json:"login_date" db:"login_date" redis:"login_date"`
@@ -98,11 +101,12 @@ type UsersList struct {
 	Page       int     `json:"page"`
 	Size       int     `json:"size"`
 	HasMore    bool    `json:"has_more"`
+	NextCursor string  `json:"next_cursor,omitempty"`
 	Users      []*User `json:"users"`
 }
 
-// Find user query
+// User with tokens, tokens are only issued in token auth mode
 type UserWithToken struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User   *User       `json:"user"`
+	Tokens *AuthTokens `json:"tokens,omitempty"`
 }
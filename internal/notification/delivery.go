@@ -0,0 +1,11 @@
+package notification
+
+import "github.com/labstack/echo/v4"
+
+// Notification HTTP Handlers interface
+type Handlers interface {
+	GetNotifications() echo.HandlerFunc
+	GetUnreadCount() echo.HandlerFunc
+	MarkRead() echo.HandlerFunc
+	MarkAllRead() echo.HandlerFunc
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// Notification handlers
+type NotificationHandlers struct {
+	cfg            *config.Config
+	notificationUC notification.UseCase
+	logger         logger.Logger
+}
+
+// NewNotificationHandlers Notification handlers constructor
+func NewNotificationHandlers(cfg *config.Config, notificationUC notification.UseCase, logger logger.Logger) notification.Handlers {
+	return &NotificationHandlers{cfg: cfg, notificationUC: notificationUC, logger: logger}
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get the list of notifications of current user, newest activity first
+// @Tags Notification
+// @Accept json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.NotificationsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications [get]
+func (h *NotificationHandlers) GetNotifications() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "NotificationHandlers.GetNotifications", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		notificationsList, err := h.notificationUC.GetNotifications(ctx, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, notificationsList)
+	}
+}
+
+// GetUnreadCount godoc
+// @Summary Get unread notifications count
+// @Description Get the number of unread notifications of current user
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.UnreadCount
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/unread_count [get]
+func (h *NotificationHandlers) GetUnreadCount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "NotificationHandlers.GetUnreadCount", nil)
+		defer span.End()
+
+		unreadCount, err := h.notificationUC.GetUnreadCount(ctx)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, unreadCount)
+	}
+}
+
+// MarkRead godoc
+// @Summary Mark notification as read
+// @Description Mark notification of current user as read
+// @Tags Notification
+// @Accept json
+// @Param id path int true "notification_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandlers) MarkRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "NotificationHandlers.MarkRead", nil)
+		defer span.End()
+
+		notificationID, err := strconv.ParseUint(c.Param("notification_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.notificationUC.MarkRead(ctx, notificationID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of current user as read
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /notifications/read [post]
+func (h *NotificationHandlers) MarkAllRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "NotificationHandlers.MarkAllRead", nil)
+		defer span.End()
+
+		if err := h.notificationUC.MarkAllRead(ctx); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
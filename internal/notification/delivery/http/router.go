@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/labstack/echo/v4"
+)
+
+// Map notification routes
+func MapNotificationRoutes(notificationGroup *echo.Group, h notification.Handlers, mw *middleware.MiddlewareManager) {
+	notificationGroup.Use(mw.AuthSessionMiddleware)
+	notificationGroup.GET("", h.GetNotifications())
+	notificationGroup.GET("/unread_count", h.GetUnreadCount())
+	notificationGroup.POST("/read", h.MarkAllRead(), mw.CSRF)
+	notificationGroup.POST("/:notification_id/read", h.MarkRead(), mw.CSRF)
+}
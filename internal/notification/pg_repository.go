@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Notification repository interface
+type Repository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.NotificationsList, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID uint64) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
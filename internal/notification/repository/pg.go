@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Notification repository
+type notificationRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) notification.Repository {
+	return &notificationRepo{db: db}
+}
+
+// Add actor to the unread notification of the same event, creating it if missing
+func (r *notificationRepo) Create(ctx context.Context, n *models.Notification) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationRepo.Create", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, createNotificationQuery, n.UserID.String(), n.Type, n.TweetID, n.ActorID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "notificationRepo.Create.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) GetNotifications(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.NotificationsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "notificationRepo.GetNotifications", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getNotificationsTotal, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "notificationRepo.GetNotifications.GetContext.getNotificationsTotal")
+	}
+
+	if totalCount == 0 {
+		return &models.NotificationsList{
+			TotalCount:    totalCount,
+			TotalPages:    utils.GetTotalPages(totalCount, pq.GetSize()),
+			Page:          pq.GetPage(),
+			Size:          pq.GetSize(),
+			HasMore:       utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+			Notifications: make([]*models.Notification, 0),
+		}, nil
+	}
+
+	var notifications = make([]*models.Notification, 0, pq.GetSize())
+	if err := r.db.SelectContext(ctx, &notifications, getNotifications, userID.String(), pq.GetOffset(), pq.GetLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "notificationRepo.GetNotifications.SelectContext")
+	}
+
+	return &models.NotificationsList{
+		TotalCount:    totalCount,
+		TotalPages:    utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:          pq.GetPage(),
+		Size:          pq.GetSize(),
+		HasMore:       utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Notifications: notifications,
+	}, nil
+}
+
+func (r *notificationRepo) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, span := tracer.NewSpan(ctx, "notificationRepo.GetUnreadCount", nil)
+	defer span.End()
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, getUnreadCount, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "notificationRepo.GetUnreadCount.GetContext")
+	}
+
+	return count, nil
+}
+
+func (r *notificationRepo) MarkRead(ctx context.Context, userID uuid.UUID, notificationID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationRepo.MarkRead", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, markReadQuery, userID.String(), notificationID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "notificationRepo.MarkRead.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "notificationRepo.MarkRead.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "notificationRepo.MarkRead.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationRepo.MarkAllRead", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, markAllReadQuery, userID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "notificationRepo.MarkAllRead.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+const (
+	createNotificationQuery = `WITH __n AS
+								(INSERT INTO notifications (user_id, type, tweet_id, created_at, updated_at)
+								VALUES ($1, $2, $3, now(), now())
+								ON CONFLICT (user_id, type, (COALESCE(tweet_id, 0))) WHERE read_at IS NULL
+								DO UPDATE SET updated_at = now()
+								RETURNING id
+								)
+							   INSERT INTO notification_actors (notification_id, actor_id, created_at)
+							   SELECT __n.id, $4, now() FROM __n
+							   ON CONFLICT (notification_id, actor_id) DO UPDATE SET created_at = now()`
+
+	getNotificationsTotal = `SELECT COUNT(n.id) FROM notifications n
+							 WHERE n.user_id = $1
+							 AND EXISTS (SELECT 1 FROM notification_actors na WHERE na.notification_id = n.id)`
+
+	getNotifications = `SELECT n.id, n.user_id, n.type, n.tweet_id, t.text AS tweet_text,
+						a.actor_id, u.user_name AS actor_user_name, u.name AS actor_name, u.avatar AS actor_avatar,
+						(SELECT COUNT(na.actor_id) FROM notification_actors na WHERE na.notification_id = n.id) AS actors_count,
+						n.read_at, n.created_at, n.updated_at
+						FROM notifications n
+						INNER JOIN LATERAL (SELECT la.actor_id FROM notification_actors la
+											WHERE la.notification_id = n.id
+											ORDER BY la.created_at desc LIMIT 1) a ON true
+						INNER JOIN users u ON u.user_id = a.actor_id
+						LEFT JOIN tweets t ON t.id = n.tweet_id
+						WHERE n.user_id = $1
+						ORDER BY n.updated_at desc, n.id desc
+						OFFSET $2 LIMIT $3`
+
+	getUnreadCount = `SELECT COUNT(n.id) FROM notifications n
+					  WHERE n.user_id = $1 AND n.read_at IS NULL
+					  AND EXISTS (SELECT 1 FROM notification_actors na WHERE na.notification_id = n.id)`
+
+	markReadQuery = `UPDATE notifications SET read_at = COALESCE(read_at, now())
+					 WHERE user_id = $1 AND id = $2`
+
+	markAllReadQuery = `UPDATE notifications SET read_at = now()
+						WHERE user_id = $1 AND read_at IS NULL`
+)
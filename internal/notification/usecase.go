@@ -0,0 +1,17 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+)
+
+// Notification usecase interface
+type UseCase interface {
+	Notify(ctx context.Context, notification *models.Notification) error
+	GetNotifications(ctx context.Context, pq *utils.PaginationQuery) (*models.NotificationsList, error)
+	GetUnreadCount(ctx context.Context) (*models.UnreadCount, error)
+	MarkRead(ctx context.Context, notificationID uint64) error
+	MarkAllRead(ctx context.Context) error
+}
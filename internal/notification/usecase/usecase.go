@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// Notification UseCase
+type notificationUC struct {
+	cfg              *config.Config
+	notificationRepo notification.Repository
+	streamUC         stream.UseCase
+	logger           logger.Logger
+}
+
+// Notification UseCase constructor
+func NewNotificationUseCase(cfg *config.Config, notificationRepo notification.Repository, streamUC stream.UseCase, logger logger.Logger) notification.UseCase {
+	return &notificationUC{cfg: cfg, notificationRepo: notificationRepo, streamUC: streamUC, logger: logger}
+}
+
+// Record an event for the recipient, users are not notified of their own actions
+func (u *notificationUC) Notify(ctx context.Context, n *models.Notification) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationUC.Notify", nil)
+	defer span.End()
+
+	if n.UserID == n.ActorID {
+		return nil
+	}
+
+	if err := u.notificationRepo.Create(ctx, n); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if err := u.streamUC.Publish(ctx, models.EventNotification, n, n.UserID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("notificationUC.Notify.Publish: %v", err)
+	}
+
+	return nil
+}
+
+// Get notifications of current user, newest activity first
+func (u *notificationUC) GetNotifications(ctx context.Context, pq *utils.PaginationQuery) (*models.NotificationsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "notificationUC.GetNotifications", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.GetNotifications.GetUserFromCtx"))
+	}
+
+	notificationsList, err := u.notificationRepo.GetNotifications(ctx, self.UserID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	for _, n := range notificationsList.Notifications {
+		n.Message = notificationMessage(n)
+	}
+
+	return notificationsList, nil
+}
+
+// Get unread notifications count of current user
+func (u *notificationUC) GetUnreadCount(ctx context.Context) (*models.UnreadCount, error) {
+	ctx, span := tracer.NewSpan(ctx, "notificationUC.GetUnreadCount", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.GetUnreadCount.GetUserFromCtx"))
+	}
+
+	count, err := u.notificationRepo.GetUnreadCount(ctx, self.UserID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return &models.UnreadCount{Count: count}, nil
+}
+
+// Mark notification of current user as read
+func (u *notificationUC) MarkRead(ctx context.Context, notificationID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationUC.MarkRead", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.MarkRead.GetUserFromCtx"))
+	}
+
+	return u.notificationRepo.MarkRead(ctx, self.UserID, notificationID)
+}
+
+// Mark all notifications of current user as read
+func (u *notificationUC) MarkAllRead(ctx context.Context) error {
+	ctx, span := tracer.NewSpan(ctx, "notificationUC.MarkAllRead", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "notificationUC.MarkAllRead.GetUserFromCtx"))
+	}
+
+	return u.notificationRepo.MarkAllRead(ctx, self.UserID)
+}
+
+// Build display text such as "X and 12 others liked your tweet"
+func notificationMessage(n *models.Notification) string {
+	var action string
+	switch n.Type {
+	case models.NotificationLike:
+		action = "liked your tweet"
+	case models.NotificationFollow:
+		action = "followed you"
+	case models.NotificationFollowRequest:
+		action = "requested to follow you"
+	case models.NotificationFollowAccept:
+		action = "accepted your follow request"
+	case models.NotificationReply:
+		action = "replied to your tweet"
+	case models.NotificationMention:
+		action = "mentioned you"
+	default:
+		action = n.Type
+	}
+
+	switch others := n.ActorsCount - 1; {
+	case others <= 0:
+		return fmt.Sprintf("%s %s", n.ActorName, action)
+	case others == 1:
+		return fmt.Sprintf("%s and 1 other %s", n.ActorName, action)
+	default:
+		return fmt.Sprintf("%s and %d others %s", n.ActorName, others, action)
+	}
+}
@@ -1,27 +1,62 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	authHttp "github.com/JamesHsu333/go-twitter/internal/auth/delivery/http"
+	authRepository "github.com/JamesHsu333/go-twitter/internal/auth/repository"
+	authUseCase "github.com/JamesHsu333/go-twitter/internal/auth/usecase"
+	blockRepository "github.com/JamesHsu333/go-twitter/internal/block/repository"
+	blockUseCase "github.com/JamesHsu333/go-twitter/internal/block/usecase"
+	bookmarkRepository "github.com/JamesHsu333/go-twitter/internal/bookmark/repository"
+	bookmarkUseCase "github.com/JamesHsu333/go-twitter/internal/bookmark/usecase"
+	draftHttp "github.com/JamesHsu333/go-twitter/internal/draft/delivery/http"
+	draftRepository "github.com/JamesHsu333/go-twitter/internal/draft/repository"
+	draftUseCase "github.com/JamesHsu333/go-twitter/internal/draft/usecase"
 	fileRepository "github.com/JamesHsu333/go-twitter/internal/file/repository"
 	fileUseCase "github.com/JamesHsu333/go-twitter/internal/file/usecase"
 	followRepository "github.com/JamesHsu333/go-twitter/internal/follow/repository"
 	followUseCase "github.com/JamesHsu333/go-twitter/internal/follow/usecase"
+	hashtagHttp "github.com/JamesHsu333/go-twitter/internal/hashtag/delivery/http"
+	hashtagRepository "github.com/JamesHsu333/go-twitter/internal/hashtag/repository"
+	hashtagUseCase "github.com/JamesHsu333/go-twitter/internal/hashtag/usecase"
 	likeRepository "github.com/JamesHsu333/go-twitter/internal/like/repository"
 	likeUseCase "github.com/JamesHsu333/go-twitter/internal/like/usecase"
+	listHttp "github.com/JamesHsu333/go-twitter/internal/list/delivery/http"
+	listRepository "github.com/JamesHsu333/go-twitter/internal/list/repository"
+	listUseCase "github.com/JamesHsu333/go-twitter/internal/list/usecase"
+	mediaHttp "github.com/JamesHsu333/go-twitter/internal/media/delivery/http"
+	mediaRepository "github.com/JamesHsu333/go-twitter/internal/media/repository"
+	mediaUseCase "github.com/JamesHsu333/go-twitter/internal/media/usecase"
+	messageHttp "github.com/JamesHsu333/go-twitter/internal/message/delivery/http"
+	messageRepository "github.com/JamesHsu333/go-twitter/internal/message/repository"
+	messageUseCase "github.com/JamesHsu333/go-twitter/internal/message/usecase"
 	apiMiddlewares "github.com/JamesHsu333/go-twitter/internal/middleware"
+	notificationHttp "github.com/JamesHsu333/go-twitter/internal/notification/delivery/http"
+	notificationRepository "github.com/JamesHsu333/go-twitter/internal/notification/repository"
+	notificationUseCase "github.com/JamesHsu333/go-twitter/internal/notification/usecase"
 	sessionRepository "github.com/JamesHsu333/go-twitter/internal/session/repository"
 	"github.com/JamesHsu333/go-twitter/internal/session/usecase"
+	streamHttp "github.com/JamesHsu333/go-twitter/internal/stream/delivery/http"
+	streamRepository "github.com/JamesHsu333/go-twitter/internal/stream/repository"
+	streamUseCase "github.com/JamesHsu333/go-twitter/internal/stream/usecase"
 	tweetHttp "github.com/JamesHsu333/go-twitter/internal/tweet/delivery/http"
 	tweetRepository "github.com/JamesHsu333/go-twitter/internal/tweet/repository"
 	tweetUseCase "github.com/JamesHsu333/go-twitter/internal/tweet/usecase"
+	uploadHttp "github.com/JamesHsu333/go-twitter/internal/upload/delivery/http"
+	uploadRepository "github.com/JamesHsu333/go-twitter/internal/upload/repository"
+	uploadUseCase "github.com/JamesHsu333/go-twitter/internal/upload/usecase"
 	userHttp "github.com/JamesHsu333/go-twitter/internal/user/delivery/http"
 	userRepository "github.com/JamesHsu333/go-twitter/internal/user/repository"
 	userUseCase "github.com/JamesHsu333/go-twitter/internal/user/usecase"
 	"github.com/JamesHsu333/go-twitter/pkg/csrf"
 	"github.com/JamesHsu333/go-twitter/pkg/metric"
 	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/pkg/errors"
 	"golang.org/x/time/rate"
 
 	"github.com/labstack/echo/v4"
@@ -42,37 +77,97 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		s.cfg.Metrics.ServiceName,
 	)
 
+	fileRepo, err := fileRepository.NewFileRepository(s.cfg)
+	if err != nil {
+		return err
+	}
+
+	if err = validateAuthConfig(s.cfg); err != nil {
+		return err
+	}
+
 	// Init repositories
 	aRepo := userRepository.NewUserRepository(s.db)
 	tRepo := tweetRepository.NewTweetRepository(s.db)
+	tweetRedisRepo := tweetRepository.NewTweetRedisRepo(s.redisClient)
 	sRepo := sessionRepository.NewSessionRepository(s.redisClient, s.cfg)
 	userRedisRepo := userRepository.NewUserRedisRepo(s.redisClient)
-	fileRepo := fileRepository.NewFileRepository(s.cfg)
 	followRepo := followRepository.NewFollowRepository(s.db)
+	blockRepo := blockRepository.NewBlockRepository(s.db)
+	listRepo := listRepository.NewListRepository(s.db)
 	followRedisRepo := followRepository.NewFollowRedisRepo(s.redisClient)
 	likeRepo := likeRepository.NewLikeRepository(s.db)
+	bookmarkRepo := bookmarkRepository.NewBookmarkRepository(s.db)
+	hashtagRepo := hashtagRepository.NewHashtagRepository(s.db)
+	hashtagRedisRepo := hashtagRepository.NewHashtagRedisRepo(s.redisClient)
+	notificationRepo := notificationRepository.NewNotificationRepository(s.db)
+	streamRedisRepo := streamRepository.NewStreamRedisRepo(s.redisClient)
+	messageRepo := messageRepository.NewMessageRepository(s.db)
+	draftRepo := draftRepository.NewDraftRepository(s.db)
+	mediaRepo := mediaRepository.NewMediaRepository(s.db)
+	uploadRepo := uploadRepository.NewUploadRepository(s.db)
+	objectRepo := fileRepository.NewObjectRepository(s.db)
+	authRepo := authRepository.NewAuthRepository(s.db)
+	authRedisRepo := authRepository.NewAuthRedisRepo(s.redisClient)
 
 	// Init useCases
 	userUC := userUseCase.NewUserUseCase(s.cfg, aRepo, userRedisRepo, followRedisRepo, s.logger)
 	sessUC := usecase.NewSessionUseCase(sRepo, s.cfg)
-	tweetUC := tweetUseCase.NewTweetUseCase(s.cfg, tRepo, s.logger)
-	fileUC := fileUseCase.NewFileUseCase(s.cfg, fileRepo, s.logger)
-	followUC := followUseCase.NewFollowUseCase(s.cfg, followRepo, followRedisRepo, s.logger)
-	likeUC := likeUseCase.NewLikeUseCase(s.cfg, likeRepo, s.logger)
+	authUC := authUseCase.NewAuthUseCase(s.cfg, authRepo, authRedisRepo, aRepo, s.logger)
+	streamUC := streamUseCase.NewStreamUseCase(s.cfg, streamRedisRepo, s.logger)
+	notificationUC := notificationUseCase.NewNotificationUseCase(s.cfg, notificationRepo, streamUC, s.logger)
+	hashtagUC := hashtagUseCase.NewHashtagUseCase(s.cfg, hashtagRepo, hashtagRedisRepo, s.logger)
+	tweetUC := tweetUseCase.NewTweetUseCase(s.cfg, tRepo, tweetRedisRepo, followRepo, aRepo, hashtagUC, notificationUC, streamUC, s.logger)
+	fileUC := fileUseCase.NewFileUseCase(s.cfg, fileRepo, objectRepo, s.logger)
+	followUC := followUseCase.NewFollowUseCase(s.cfg, followRepo, followRedisRepo, blockRepo, tRepo, tweetRedisRepo, notificationUC, streamUC, s.logger)
+	blockUC := blockUseCase.NewBlockUseCase(s.cfg, blockRepo, followUC, s.logger)
+	likeUC := likeUseCase.NewLikeUseCase(s.cfg, likeRepo, tRepo, notificationUC, streamUC, s.logger)
+	bookmarkUC := bookmarkUseCase.NewBookmarkUseCase(s.cfg, bookmarkRepo, tRepo, s.logger)
+	messageUC := messageUseCase.NewMessageUseCase(s.cfg, messageRepo, followRepo, blockRepo, streamUC, s.logger)
+	listUC := listUseCase.NewListUseCase(s.cfg, listRepo, blockRepo, s.logger)
+	draftUC := draftUseCase.NewDraftUseCase(s.cfg, draftRepo, aRepo, tweetUC, s.logger)
+	mediaUC := mediaUseCase.NewMediaUseCase(s.cfg, mediaRepo, fileUC, s.logger)
+	uploadUC := uploadUseCase.NewUploadUseCase(s.cfg, uploadRepo, fileRepo, fileUC, mediaUC, userUC, s.logger)
 
 	// Init handlers
-	userHandlers := userHttp.NewUserHandlers(s.cfg, userUC, sessUC, fileUC, followUC, likeUC, tweetUC, s.logger)
-	tweetHandlers := tweetHttp.NewTweetHandlers(s.cfg, tweetUC, fileUC, likeUC, s.logger)
+	userHandlers := userHttp.NewUserHandlers(s.cfg, userUC, sessUC, authUC, fileUC, followUC, blockUC, likeUC, bookmarkUC, tweetUC, s.logger)
+	tweetHandlers := tweetHttp.NewTweetHandlers(s.cfg, tweetUC, fileUC, likeUC, mediaUC, s.logger)
+	hashtagHandlers := hashtagHttp.NewHashtagHandlers(s.cfg, hashtagUC, s.logger)
+	notificationHandlers := notificationHttp.NewNotificationHandlers(s.cfg, notificationUC, s.logger)
+	streamHandlers := streamHttp.NewStreamHandlers(s.cfg, streamUC, s.logger)
+	messageHandlers := messageHttp.NewMessageHandlers(s.cfg, messageUC, fileUC, s.logger)
+	listHandlers := listHttp.NewListHandlers(s.cfg, listUC, s.logger)
+	draftHandlers := draftHttp.NewDraftHandlers(s.cfg, draftUC, fileUC, s.logger)
+	mediaHandlers := mediaHttp.NewMediaHandlers(s.cfg, mediaUC, s.logger)
+	uploadHandlers := uploadHttp.NewUploadHandlers(s.cfg, uploadUC, s.logger)
+	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC, s.logger)
+
+	// Scheduled drafts are claimed in postgres, the scheduler can run on every replica
+	if s.cfg.Draft.SchedulerEnabled {
+		go draftUC.RunScheduler(context.Background())
+	}
 
-	mw := apiMiddlewares.NewMiddlewareManager(sessUC, userUC, s.cfg, []string{"*"}, s.logger)
+	// Orphaned files are reported every interval, and removed unless in dry run
+	if s.cfg.FileGC.Enabled {
+		orphanMetrics, err := metric.CreateOrphanMetrics(s.cfg.Metrics.ServiceName)
+		if err != nil {
+			return err
+		}
+		collector := fileUseCase.NewCollector(s.cfg, fileRepository.NewRecordRepository(s.db), fileRepo, orphanMetrics, s.logger)
+		go collector.RunScheduler(context.Background())
+	}
+
+	mw := apiMiddlewares.NewMiddlewareManager(sessUC, authUC, userUC, tweetUC, s.cfg, []string{"*"}, s.logger)
 
 	e.Use(mw.RequestLoggerMiddleware)
 
 	docs.SwaggerInfo.Title = "Go example REST API"
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	// Static file
-	e.Static(s.cfg.File.FilePath, s.cfg.File.FilePath)
+	// Static file, objects of other drivers are served by the storage itself
+	if s.cfg.File.Driver == "" || s.cfg.File.Driver == fileRepository.LocalDriver {
+		e.Static(s.cfg.File.FilePath, s.cfg.File.FilePath)
+	}
 
 	if s.cfg.Server.SSL {
 		e.Pre(middleware.HTTPSRedirect())
@@ -80,7 +175,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, echo.HeaderAuthorization, csrf.CSRFHeader},
 	}))
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		StackSize:         1 << 10, // 1 KB
@@ -93,7 +188,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			return strings.Contains(c.Request().URL.Path, "swagger") || strings.HasPrefix(c.Request().URL.Path, "/api/v1/stream")
 		},
 	}))
 	e.Use(middleware.Secure())
@@ -110,9 +205,31 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	health := v1.Group("/health")
 	userGroup := v1.Group("/users")
 	tweetGroup := v1.Group("/tweets")
+	hashtagGroup := v1.Group("/hashtags")
+	trendGroup := v1.Group("/trends")
+	notificationGroup := v1.Group("/notifications")
+	streamGroup := v1.Group("/stream")
+	conversationGroup := v1.Group("/conversations")
+	listGroup := v1.Group("/lists")
+	draftGroup := v1.Group("/drafts")
+	mediaGroup := v1.Group("/media")
+	uploadGroup := v1.Group("/uploads")
+	authGroup := v1.Group("/auth")
 
 	userHttp.MapUserRoutes(userGroup, userHandlers, mw)
 	tweetHttp.MapTweetRoutes(tweetGroup, tweetHandlers, mw)
+	hashtagHttp.MapHashtagRoutes(hashtagGroup, hashtagHandlers, mw)
+	hashtagHttp.MapTrendRoutes(trendGroup, hashtagHandlers, mw)
+	notificationHttp.MapNotificationRoutes(notificationGroup, notificationHandlers, mw)
+	streamHttp.MapStreamRoutes(streamGroup, streamHandlers, mw)
+	messageHttp.MapMessageRoutes(conversationGroup, messageHandlers, mw)
+	listHttp.MapListRoutes(listGroup, listHandlers, mw)
+	draftHttp.MapDraftRoutes(draftGroup, draftHandlers, mw)
+	mediaHttp.MapMediaRoutes(mediaGroup, mediaHandlers, mw)
+	uploadHttp.MapUploadRoutes(uploadGroup, uploadHandlers, mw)
+	if s.cfg.Auth.Mode == auth.TokenMode {
+		authHttp.MapAuthRoutes(authGroup, authHandlers)
+	}
 
 	health.GET("", func(c echo.Context) error {
 		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
@@ -121,3 +238,18 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	return nil
 }
+
+// Refuse unknown auth modes, and token mode without the signing key in the key set
+func validateAuthConfig(cfg *config.Config) error {
+	switch cfg.Auth.Mode {
+	case "", auth.SessionMode:
+		return nil
+	case auth.TokenMode:
+		if cfg.Auth.Keys[strings.ToLower(cfg.Auth.SigningKeyID)] == "" {
+			return errors.Errorf("auth signing key %q is not in the key set", cfg.Auth.SigningKeyID)
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown auth mode %q", cfg.Auth.Mode)
+	}
+}
@@ -0,0 +1,8 @@
+package stream
+
+import "github.com/labstack/echo/v4"
+
+// Stream HTTP Handlers interface
+type Handlers interface {
+	Stream() echo.HandlerFunc
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+	retryMillis       = 1000
+)
+
+// Stream handlers
+type StreamHandlers struct {
+	cfg      *config.Config
+	streamUC stream.UseCase
+	logger   logger.Logger
+}
+
+// NewStreamHandlers Stream handlers constructor
+func NewStreamHandlers(cfg *config.Config, streamUC stream.UseCase, logger logger.Logger) stream.Handlers {
+	return &StreamHandlers{cfg: cfg, streamUC: streamUC, logger: logger}
+}
+
+// Stream godoc
+// @Summary Stream events
+// @Description Server-Sent Events stream of new tweets, like counts, follows, notifications and messages of current user.
+// @Description Send Last-Event-ID header or last_event_id query to replay missed events.
+// @Tags Stream
+// @Produce text/event-stream
+// @Param last_event_id query string false "last received event id"
+// @Success 200 {string} string "event stream"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /stream [get]
+func (h *StreamHandlers) Stream() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "StreamHandlers.Stream", nil)
+		defer span.End()
+
+		lastEventID := c.Request().Header.Get(lastEventIDHeader)
+		if lastEventID == "" {
+			lastEventID = c.QueryParam("last_event_id")
+		}
+
+		// End the response before the server write timeout cuts it, the client reconnects with Last-Event-ID
+		var cancel context.CancelFunc
+		if writeTimeout := h.cfg.Server.WriteTimeout * time.Second; writeTimeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, writeTimeout-writeTimeout/5)
+		} else {
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		defer cancel()
+
+		events, err := h.streamUC.Subscribe(ctx, lastEventID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set("Cache-Control", "no-cache")
+		res.Header().Set("Connection", "keep-alive")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+
+		if _, err = fmt.Fprintf(res, "retry: %d\n\n", retryMillis); err != nil {
+			return nil
+		}
+		res.Flush()
+
+		heartbeat := time.NewTicker(time.Duration(h.cfg.Stream.HeartbeatSeconds) * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return nil
+				}
+				if _, err = fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+					return nil
+				}
+				res.Flush()
+			case <-heartbeat.C:
+				if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+					return nil
+				}
+				res.Flush()
+			}
+		}
+	}
+}
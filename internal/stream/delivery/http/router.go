@@ -0,0 +1,13 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/labstack/echo/v4"
+)
+
+// Map stream routes
+func MapStreamRoutes(streamGroup *echo.Group, h stream.Handlers, mw *middleware.MiddlewareManager) {
+	streamGroup.Use(mw.AuthSessionMiddleware)
+	streamGroup.GET("", h.Stream())
+}
@@ -0,0 +1,15 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+)
+
+// Stream Redis repository interface
+type RedisRepository interface {
+	AddEventsCtx(ctx context.Context, keys []string, maxLen int64, seconds int, events []*models.Event) error
+	GetEventsAfterCtx(ctx context.Context, key string, lastID string, count int64) ([]*models.Event, error)
+	PublishEventsCtx(ctx context.Context, channel string, events []*models.Event) error
+	SubscribeEventsCtx(ctx context.Context, channel string, handler func(event *models.Event)) error
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Stream redis repository
+type streamRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Stream redis repository constructor
+func NewStreamRedisRepo(redisClient *redis.Client) stream.RedisRepository {
+	return &streamRedisRepo{redisClient: redisClient}
+}
+
+// Append each event to its capped redis stream kept for replay, filling event ids
+func (a *streamRedisRepo) AddEventsCtx(ctx context.Context, keys []string, maxLen int64, seconds int, events []*models.Event) error {
+	ctx, span := tracer.NewSpan(ctx, "streamRedisRepo.AddEventsCtx", nil)
+	defer span.End()
+
+	pipe := a.redisClient.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(events))
+	for i, event := range events {
+		cmds = append(cmds, pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: keys[i],
+			MaxLen: maxLen,
+			Approx: true,
+			Values: map[string]interface{}{"type": event.Type, "data": string(event.Data)},
+		}))
+		pipe.Expire(ctx, keys[i], time.Second*time.Duration(seconds))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "streamRedisRepo.AddEventsCtx.pipe.Exec")
+	}
+
+	for i, cmd := range cmds {
+		events[i].ID = cmd.Val()
+	}
+	return nil
+}
+
+// Get events added after lastID, oldest first
+func (a *streamRedisRepo) GetEventsAfterCtx(ctx context.Context, key string, lastID string, count int64) ([]*models.Event, error) {
+	ctx, span := tracer.NewSpan(ctx, "streamRedisRepo.GetEventsAfterCtx", nil)
+	defer span.End()
+
+	events := make([]*models.Event, 0)
+	streams, err := a.redisClient.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{key, lastID},
+		Count:   count,
+		Block:   -1,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return events, nil
+		}
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "streamRedisRepo.GetEventsAfterCtx.redisClient.XRead")
+	}
+
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			eventType, _ := m.Values["type"].(string)
+			data, _ := m.Values["data"].(string)
+			events = append(events, &models.Event{ID: m.ID, Type: eventType, Data: json.RawMessage(data)})
+		}
+	}
+	return events, nil
+}
+
+func (a *streamRedisRepo) PublishEventsCtx(ctx context.Context, channel string, events []*models.Event) error {
+	ctx, span := tracer.NewSpan(ctx, "streamRedisRepo.PublishEventsCtx", nil)
+	defer span.End()
+
+	pipe := a.redisClient.Pipeline()
+	for _, event := range events {
+		eventBytes, err := json.Marshal(event)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return errors.Wrap(err, "streamRedisRepo.PublishEventsCtx.json.Marshal")
+		}
+		pipe.Publish(ctx, channel, eventBytes)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "streamRedisRepo.PublishEventsCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Receive published events until ctx is done
+func (a *streamRedisRepo) SubscribeEventsCtx(ctx context.Context, channel string, handler func(event *models.Event)) error {
+	pubsub := a.redisClient.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return errors.Wrap(err, "streamRedisRepo.SubscribeEventsCtx.pubsub.Receive")
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return errors.New("streamRedisRepo.SubscribeEventsCtx: channel closed")
+			}
+			event := &models.Event{}
+			if err := json.Unmarshal([]byte(msg.Payload), event); err != nil {
+				continue
+			}
+			handler(event)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
+)
+
+// Stream usecase interface
+type UseCase interface {
+	Publish(ctx context.Context, eventType string, data interface{}, userIDs ...uuid.UUID) error
+	Subscribe(ctx context.Context, lastEventID string) (<-chan *models.Event, error)
+}
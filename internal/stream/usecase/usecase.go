@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	basePrefix       = "api-twitter:"
+	resubscribeDelay = time.Second
+)
+
+// Local connection of a user
+type client struct {
+	events chan *models.Event
+}
+
+// Stream UseCase.
+// Events are stored per user in a capped redis stream for Last-Event-ID replay
+// and broadcast over redis pub/sub, every replica delivering them to its own connections.
+type streamUC struct {
+	cfg             *config.Config
+	streamRedisRepo stream.RedisRepository
+	logger          logger.Logger
+	once            sync.Once
+	mu              sync.Mutex
+	clients         map[uuid.UUID]map[*client]struct{}
+}
+
+// Stream UseCase constructor
+func NewStreamUseCase(cfg *config.Config, streamRedisRepo stream.RedisRepository, logger logger.Logger) stream.UseCase {
+	return &streamUC{
+		cfg:             cfg,
+		streamRedisRepo: streamRedisRepo,
+		logger:          logger,
+		clients:         make(map[uuid.UUID]map[*client]struct{}),
+	}
+}
+
+// Publish event to the streams of users
+func (u *streamUC) Publish(ctx context.Context, eventType string, data interface{}, userIDs ...uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "streamUC.Publish", nil)
+	defer span.End()
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "streamUC.Publish.json.Marshal")
+	}
+
+	keys := make([]string, 0, len(userIDs))
+	events := make([]*models.Event, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, u.generateStreamKey(userID.String()))
+		events = append(events, &models.Event{UserID: userID, Type: eventType, Data: payload})
+	}
+
+	if err = u.streamRedisRepo.AddEventsCtx(ctx, keys, u.cfg.Stream.ReplaySize, u.cfg.Stream.ReplayTTLSeconds, events); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if err = u.streamRedisRepo.PublishEventsCtx(ctx, u.generateChannelKey(), events); err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// Subscribe current user to the event stream until ctx is done.
+// Events after lastEventID are replayed first. The channel is closed when the
+// client falls more than the buffer size behind, it should reconnect and replay.
+func (u *streamUC) Subscribe(ctx context.Context, lastEventID string) (<-chan *models.Event, error) {
+	ctx, span := tracer.NewSpan(ctx, "streamUC.Subscribe", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "streamUC.Subscribe.GetUserFromCtx"))
+	}
+
+	if lastEventID != "" {
+		if _, _, err = parseEventID(lastEventID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "streamUC.Subscribe.parseEventID"))
+		}
+	}
+
+	u.once.Do(func() {
+		go u.run()
+	})
+
+	// Register before reading the replay so no event falls in between
+	c := &client{events: make(chan *models.Event, u.cfg.Stream.BufferSize)}
+	u.register(self.UserID, c)
+
+	var replay []*models.Event
+	if lastEventID != "" {
+		replay, err = u.streamRedisRepo.GetEventsAfterCtx(ctx, u.generateStreamKey(self.UserID.String()), lastEventID, u.cfg.Stream.ReplaySize)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			u.unregister(self.UserID, c)
+			return nil, err
+		}
+	}
+
+	out := make(chan *models.Event)
+	go func() {
+		defer close(out)
+		defer u.unregister(self.UserID, c)
+
+		lastID := lastEventID
+		for _, event := range replay {
+			select {
+			case out <- event:
+				lastID = event.ID
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-c.events:
+				if !ok {
+					return
+				}
+				if lastID != "" && !eventIDAfter(event.ID, lastID) {
+					continue
+				}
+				select {
+				case out <- event:
+					lastID = event.ID
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Receive events of every user from pub/sub and hand them to local clients
+func (u *streamUC) run() {
+	for {
+		err := u.streamRedisRepo.SubscribeEventsCtx(context.Background(), u.generateChannelKey(), u.dispatch)
+		u.logger.Errorf("streamUC.run.SubscribeEventsCtx: %v", err)
+
+		// Events may have been missed, make clients reconnect and replay
+		u.disconnectAll()
+		time.Sleep(resubscribeDelay)
+	}
+}
+
+func (u *streamUC) dispatch(event *models.Event) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for c := range u.clients[event.UserID] {
+		select {
+		case c.events <- event:
+		default:
+			// Slow client, drop it rather than block other users
+			delete(u.clients[event.UserID], c)
+			close(c.events)
+		}
+	}
+	if len(u.clients[event.UserID]) == 0 {
+		delete(u.clients, event.UserID)
+	}
+}
+
+func (u *streamUC) register(userID uuid.UUID, c *client) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.clients[userID]; !ok {
+		u.clients[userID] = make(map[*client]struct{})
+	}
+	u.clients[userID][c] = struct{}{}
+}
+
+func (u *streamUC) unregister(userID uuid.UUID, c *client) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.clients[userID][c]; ok {
+		delete(u.clients[userID], c)
+		close(c.events)
+	}
+	if len(u.clients[userID]) == 0 {
+		delete(u.clients, userID)
+	}
+}
+
+func (u *streamUC) disconnectAll() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for userID, clients := range u.clients {
+		for c := range clients {
+			close(c.events)
+		}
+		delete(u.clients, userID)
+	}
+}
+
+func (u *streamUC) generateStreamKey(user string) string {
+	return fmt.Sprintf("%s: %s %s", basePrefix, "events of", user)
+}
+
+func (u *streamUC) generateChannelKey() string {
+	return fmt.Sprintf("%s: %s", basePrefix, "events")
+}
+
+// Parse redis stream id of form <milliseconds>-<sequence>
+func parseEventID(id string) (uint64, uint64, error) {
+	parts := strings.SplitN(id, "-", 2)
+	ms, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(parts) == 1 {
+		return ms, 0, nil
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return ms, seq, nil
+}
+
+// Report whether event id a comes after b
+func eventIDAfter(a string, b string) bool {
+	aMs, aSeq, err := parseEventID(a)
+	if err != nil {
+		return true
+	}
+	bMs, bSeq, err := parseEventID(b)
+	if err != nil {
+		return true
+	}
+	return aMs > bMs || (aMs == bMs && aSeq > bSeq)
+}
@@ -6,9 +6,18 @@ import "github.com/labstack/echo/v4"
 type Handlers interface {
 	Create() echo.HandlerFunc
 	CreateReply() echo.HandlerFunc
+	CreateQuote() echo.HandlerFunc
+	Retweet() echo.HandlerFunc
+	DeleteRetweet() echo.HandlerFunc
 	GetTweetByID() echo.HandlerFunc
 	GetTweets() echo.HandlerFunc
+	GetHomeTweets() echo.HandlerFunc
+	SearchTweets() echo.HandlerFunc
 	GetReplyTweets() echo.HandlerFunc
+	GetThread() echo.HandlerFunc
 	GetLikedUsers() echo.HandlerFunc
+	Vote() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	GetHistory() echo.HandlerFunc
 	Delete() echo.HandlerFunc
 }
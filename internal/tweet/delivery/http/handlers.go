@@ -1,8 +1,7 @@
 package http
 
 import (
-	"bytes"
-	"io"
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +9,7 @@ import (
 	"github.com/JamesHsu333/go-twitter/config"
 	"github.com/JamesHsu333/go-twitter/internal/file"
 	"github.com/JamesHsu333/go-twitter/internal/like"
+	"github.com/JamesHsu333/go-twitter/internal/media"
 	"github.com/JamesHsu333/go-twitter/internal/models"
 	"github.com/JamesHsu333/go-twitter/internal/tweet"
 	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
@@ -18,20 +18,24 @@ import (
 	"github.com/JamesHsu333/go-twitter/pkg/utils"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
+const maxTweetMedia = 4
+
 // Tweet handlers
 type TweetHandlers struct {
 	cfg     *config.Config
 	tweetUC tweet.UseCase
-	fileUC  file.FileRepository
+	fileUC  file.UseCase
 	likeUC  like.Repository
+	mediaUC media.UseCase
 	logger  logger.Logger
 }
 
 // NewTweetHandlers User handlers constructor
-func NewTweetHandlers(cfg *config.Config, tweetUC tweet.UseCase, fileUC file.FileRepository, likeUC like.Repository, logger logger.Logger) tweet.Handlers {
-	return &TweetHandlers{cfg: cfg, tweetUC: tweetUC, fileUC: fileUC, likeUC: likeUC, logger: logger}
+func NewTweetHandlers(cfg *config.Config, tweetUC tweet.UseCase, fileUC file.UseCase, likeUC like.Repository, mediaUC media.UseCase, logger logger.Logger) tweet.Handlers {
+	return &TweetHandlers{cfg: cfg, tweetUC: tweetUC, fileUC: fileUC, likeUC: likeUC, mediaUC: mediaUC, logger: logger}
 }
 
 // Create godoc
@@ -39,6 +43,11 @@ func NewTweetHandlers(cfg *config.Config, tweetUC tweet.UseCase, fileUC file.Fil
 // @Description create new tweet, returns tweet
 // @Tags Tweet
 // @Accept file formData file true "Body with image file"
+// @Param media formData file false "up to 4 image files, cannot be used together with image"
+// @Param alt_text formData []string false "alt text of media files, in the same order" collectionFormat(multi)
+// @Param media_ids formData []int false "ids of media uploaded beforehand" collectionFormat(multi)
+// @Param poll_options formData []string false "2 to 4 poll options, one form value per option" collectionFormat(multi)
+// @Param poll_duration_minutes formData int false "minutes the poll stays open, one day by default"
 // @Produce json
 // @Success 201 {object} models.Tweet
 // @Router /tweets [post]
@@ -54,54 +63,17 @@ func (h *TweetHandlers) Create() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		image, err := utils.ReadImage(c, "image")
+		err := h.readTweetImage(ctx, c, tweet)
 		if err != nil {
-			if !strings.Contains(err.Error(), "no such file") {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if image != nil {
-			file, err := image.Open()
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
-			defer file.Close()
-
-			binaryImage := bytes.NewBuffer(nil)
-			if _, err = io.Copy(binaryImage, file); err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
-
-			contentType, err := utils.CheckImageFileContentType(binaryImage.Bytes())
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
-
-			reader := bytes.NewReader(binaryImage.Bytes())
-
-			imageURL, err := h.fileUC.PutObject(ctx, models.UploadInput{
-				File:        reader,
-				Name:        image.Filename,
-				Size:        image.Size,
-				ContentType: contentType,
-			})
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
-
-			tweet.Image = imageURL
-
+		if err = h.uploadMedia(ctx, c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
 		createdTweet, err := h.tweetUC.Create(ctx, tweet)
@@ -142,57 +114,69 @@ func (h *TweetHandlers) CreateReply() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		image, err := utils.ReadImage(c, "image")
-		if err != nil {
-			if !strings.Contains(err.Error(), "no such file") {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
+		if err = h.readTweetImage(ctx, c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if image != nil {
-			file, err := image.Open()
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
-			defer file.Close()
+		if err = h.uploadMedia(ctx, c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
 
-			binaryImage := bytes.NewBuffer(nil)
-			if _, err = io.Copy(binaryImage, file); err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
+		createdTweet, err := h.tweetUC.CreateReply(ctx, tweetID, tweet)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
 
-			contentType, err := utils.CheckImageFileContentType(binaryImage.Bytes())
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
+		return c.JSON(http.StatusCreated, createdTweet)
+	}
+}
 
-			reader := bytes.NewReader(binaryImage.Bytes())
+// CreateQuote godoc
+// @Summary Create new quote tweet
+// @Description create new tweet quoting another tweet, returns tweet
+// @Tags Tweet
+// @Accept file formData file true "Body with image file"
+// @Produce json
+// @Success 201 {object} models.Tweet
+// @Router /tweets/{id}/quote [post]
+func (h *TweetHandlers) CreateQuote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.CreateQuote", nil)
+		defer span.End()
 
-			imageURL, err := h.fileUC.PutObject(ctx, models.UploadInput{
-				File:        reader,
-				Name:        image.Filename,
-				Size:        image.Size,
-				ContentType: contentType,
-			})
-			if err != nil {
-				tracer.AddSpanError(span, err)
-				utils.LogResponseError(c, h.logger, err)
-				return c.JSON(httpErrors.ErrorResponse(err))
-			}
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweet := &models.Tweet{}
+		if err := utils.ReadRequest(c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
 
-			tweet.Image = imageURL
+		if err = h.readTweetImage(ctx, c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
 
+		if err = h.uploadMedia(ctx, c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		createdTweet, err := h.tweetUC.CreateReply(ctx, tweetID, tweet)
+		createdTweet, err := h.tweetUC.CreateQuote(ctx, tweetID, tweet)
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
@@ -203,6 +187,70 @@ func (h *TweetHandlers) CreateReply() echo.HandlerFunc {
 	}
 }
 
+// Retweet godoc
+// @Summary Retweet tweet
+// @Description repost tweet to the user's profile
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/{id}/retweet [post]
+func (h *TweetHandlers) Retweet() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.Retweet", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.tweetUC.Retweet(ctx, tweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// DeleteRetweet godoc
+// @Summary Undo retweet
+// @Description remove retweet from the user's profile
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/{id}/retweet [delete]
+func (h *TweetHandlers) DeleteRetweet() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.DeleteRetweet", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.tweetUC.DeleteRetweet(ctx, tweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
 // GetTweetByID godoc
 // @Summary get tweet by id
 // @Description get tweet by ID
@@ -243,7 +291,7 @@ func (h *TweetHandlers) GetTweetByID() echo.HandlerFunc {
 // @Accept json
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
-// @Param orderBy query int false "filter name" Format(orderBy)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
 // @Produce json
 // @Success 200 {object} models.TweetsList
 // @Failure 500 {object} httpErrors.RestError
@@ -288,6 +336,78 @@ func (h *TweetHandlers) GetTweets() echo.HandlerFunc {
 	}
 }
 
+// SearchTweets godoc
+// @Summary Search tweets
+// @Description Full text search of tweets, most relevant first. Supports "quoted phrases", OR, -excluded terms
+// @Description and operators from:user_name, has:image, -has:image, since:YYYY-MM-DD, until:YYYY-MM-DD and min_likes:N.
+// @Description Matched words are wrapped with <mark> in highlight.
+// @Tags Tweet
+// @Accept json
+// @Param q query string true "search query"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 400 {object} httpErrors.RestError
+// @Router /tweets/search [get]
+func (h *TweetHandlers) SearchTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.SearchTweets", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetsList, err := h.tweetUC.SearchTweets(ctx, c.QueryParam("q"), paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweetsList)
+	}
+}
+
+// GetHomeTweets godoc
+// @Summary Get home timeline
+// @Description Get the list of tweets from the user and the users they follow, newest first. The home timeline has no total count, has_more tells whether there is a next page
+// @Tags Tweet
+// @Accept json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/home [get]
+func (h *TweetHandlers) GetHomeTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.GetHomeTweets", nil)
+		defer span.End()
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetsList, err := h.tweetUC.GetHomeTweets(ctx, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweetsList)
+	}
+}
+
 // GetReplyTweets godoc
 // @Summary Get reply tweets by tweet id
 // @Description Get the list of reply tweets by tweet id
@@ -296,7 +416,7 @@ func (h *TweetHandlers) GetTweets() echo.HandlerFunc {
 // @Param id path int true "tweet_id"
 // @Param page query int false "page number" Format(page)
 // @Param size query int false "number of elements per page" Format(size)
-// @Param orderBy query int false "filter name" Format(orderBy)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
 // @Produce json
 // @Success 200 {object} models.TweetsList
 // @Failure 500 {object} httpErrors.RestError
@@ -331,6 +451,172 @@ func (h *TweetHandlers) GetReplyTweets() echo.HandlerFunc {
 	}
 }
 
+// GetThread godoc
+// @Summary Get conversation thread by tweet id
+// @Description Get the ancestor chain and the reply tree of a tweet
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Param depth query int false "max depth of the reply tree" Format(depth)
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Produce json
+// @Success 200 {object} models.TweetThread
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/{id}/thread [get]
+func (h *TweetHandlers) GetThread() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.GetThread", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		var depth int
+		if depthQuery := c.QueryParam("depth"); depthQuery != "" {
+			depth, err = strconv.Atoi(depthQuery)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+			}
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		thread, err := h.tweetUC.GetThread(ctx, tweetID, depth, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, thread)
+	}
+}
+
+// Vote godoc
+// @Summary Vote in poll
+// @Description Vote for an option of the poll of a tweet once, returns the poll with its results
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Produce json
+// @Success 201 {object} models.Poll
+// @Failure 400 {object} httpErrors.RestError
+// @Router /tweets/{id}/poll/votes [post]
+func (h *TweetHandlers) Vote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.Vote", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		vote := &models.PollVote{}
+		if err = utils.ReadRequest(c, vote); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		poll, err := h.tweetUC.Vote(ctx, tweetID, vote)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, poll)
+	}
+}
+
+// Update godoc
+// @Summary Edit tweet
+// @Description edit the text of own tweet within the edit window, previous version is kept in the edit history
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Produce json
+// @Success 200 {object} models.Tweet
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/{id} [patch]
+func (h *TweetHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.Update", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweet := &models.Tweet{}
+		if err = utils.ReadRequest(c, tweet); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		updatedTweet, err := h.tweetUC.Update(ctx, tweetID, tweet)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, updatedTweet)
+	}
+}
+
+// GetHistory godoc
+// @Summary Get edit history of tweet
+// @Description Get the current version of a tweet and its previous versions, newest first
+// @Tags Tweet
+// @Accept json
+// @Param id path int true "tweet_id"
+// @Produce json
+// @Success 200 {object} models.TweetHistory
+// @Failure 500 {object} httpErrors.RestError
+// @Router /tweets/{id}/history [get]
+func (h *TweetHandlers) GetHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "TweetHandlers.GetHistory", nil)
+		defer span.End()
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		history, err := h.tweetUC.GetHistory(ctx, tweetID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, history)
+	}
+}
+
 // Delete
 // @Summary Delete tweet by id
 // @Description delete tweet by id
@@ -353,7 +639,7 @@ func (h *TweetHandlers) Delete() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		tweet, err := h.tweetUC.GetTweetByID(ctx, tweetID)
+		tweet, err := h.tweetUC.GetStoredTweet(ctx, tweetID)
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
@@ -361,7 +647,15 @@ func (h *TweetHandlers) Delete() echo.HandlerFunc {
 		}
 
 		if tweet.Image != nil {
-			if err = h.fileUC.RemoveObject(ctx, *tweet.Image); err != nil {
+			if err = h.fileUC.RemoveImage(ctx, tweet.UserID, *tweet.Image); err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
+		for _, m := range tweet.Media {
+			if err = h.fileUC.RemoveImage(ctx, m.UserID, m.URL); err != nil {
 				tracer.AddSpanError(span, err)
 				utils.LogResponseError(c, h.logger, err)
 				return c.JSON(httpErrors.ErrorResponse(err))
@@ -415,3 +709,66 @@ func (h *TweetHandlers) GetLikedUsers() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, users)
 	}
 }
+
+// Store the image file of the request, setting it as image of tweet
+func (h *TweetHandlers) readTweetImage(ctx context.Context, c echo.Context, tweet *models.Tweet) error {
+	image, err := utils.ReadImage(c, "image")
+	if err != nil {
+		if strings.Contains(err.Error(), "no such file") {
+			return nil
+		}
+		return err
+	}
+
+	content, err := utils.ReadFile(image)
+	if err != nil {
+		return err
+	}
+
+	if _, err = utils.CheckImageFileContentType(content); err != nil {
+		return err
+	}
+
+	uploadedImage, err := h.fileUC.PutImage(ctx, content)
+	if err != nil {
+		return err
+	}
+	tweet.Image = &uploadedImage.URL
+
+	return nil
+}
+
+// Upload media files of the request with their alt text, appending them to media of tweet
+func (h *TweetHandlers) uploadMedia(ctx context.Context, c echo.Context, tweet *models.Tweet) error {
+	files, err := utils.ReadImages(c, "media")
+	if err != nil {
+		return httpErrors.NewBadRequestError(err)
+	}
+	if len(files)+len(tweet.MediaIDs) > maxTweetMedia {
+		return httpErrors.NewBadRequestError(errors.Errorf("at most %d media can be attached", maxTweetMedia))
+	}
+
+	var altTexts []string
+	if form := c.Request().MultipartForm; form != nil {
+		altTexts = form.Value["alt_text"]
+	}
+	for i, fileHeader := range files {
+		content, err := utils.ReadFile(fileHeader)
+		if err != nil {
+			return err
+		}
+
+		var altText *string
+		if i < len(altTexts) && altTexts[i] != "" {
+			altText = &altTexts[i]
+		}
+
+		uploaded, err := h.mediaUC.Upload(ctx, content, altText)
+		if err != nil {
+			return err
+		}
+		tweet.MediaIDs = append(tweet.MediaIDs, uploaded.ID)
+	}
+
+	return nil
+}
@@ -9,11 +9,20 @@ import (
 // Map tweet routes
 func MapTweetRoutes(tweetGroup *echo.Group, h tweet.Handlers, mw *middleware.MiddlewareManager) {
 	tweetGroup.Use(mw.AuthSessionMiddleware)
+	tweetGroup.GET("/home", h.GetHomeTweets())
+	tweetGroup.GET("/search", h.SearchTweets())
 	tweetGroup.GET("/:tweet_id", h.GetTweetByID())
 	tweetGroup.GET("", h.GetTweets())
 	tweetGroup.GET("/:tweet_id/replys", h.GetReplyTweets())
+	tweetGroup.GET("/:tweet_id/thread", h.GetThread())
 	tweetGroup.GET("/:tweet_id/liking_users", h.GetLikedUsers())
-	tweetGroup.DELETE("/:tweet_id", h.Delete(), mw.CSRF)
+	tweetGroup.GET("/:tweet_id/history", h.GetHistory())
+	tweetGroup.PATCH("/:tweet_id", h.Update(), mw.ResourceOwnerMiddleware(mw.TweetOwner), mw.CSRF)
+	tweetGroup.DELETE("/:tweet_id", h.Delete(), mw.TweetOwnerMiddleware(), mw.CSRF)
 	tweetGroup.POST("", h.Create(), mw.CSRF)
 	tweetGroup.POST("/:tweet_id/reply", h.CreateReply(), mw.CSRF)
+	tweetGroup.POST("/:tweet_id/quote", h.CreateQuote(), mw.CSRF)
+	tweetGroup.POST("/:tweet_id/retweet", h.Retweet(), mw.CSRF)
+	tweetGroup.DELETE("/:tweet_id/retweet", h.DeleteRetweet(), mw.CSRF)
+	tweetGroup.POST("/:tweet_id/poll/votes", h.Vote(), mw.CSRF)
 }
@@ -12,10 +12,30 @@ import (
 type Repository interface {
 	Create(ctx context.Context, tweet *models.Tweet) (*models.Tweet, error)
 	CreateReply(ctx context.Context, tweetID uint64, replyID uint64) error
+	CreateQuote(ctx context.Context, tweetID uint64, quoteID uint64) error
+	Retweet(ctx context.Context, userID uuid.UUID, tweetID uint64) error
+	DeleteRetweet(ctx context.Context, userID uuid.UUID, tweetID uint64) error
 	CheckTweetExist(ctx context.Context, tweetID uint64) error
+	GetStoredTweet(ctx context.Context, tweetID uint64) (*models.Tweet, error)
 	GetTweetByID(ctx context.Context, selfID uuid.UUID, tweetID uint64) (*models.TweetWithUser, error)
 	GetTweets(ctx context.Context, selfID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
 	GetTweetsByUserID(ctx context.Context, self uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
 	GetReplyTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetAncestorTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, maxDepth int) ([]*models.TweetWithUser, error)
+	GetDescendantTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, maxDepth int, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetTweetsByIDs(ctx context.Context, selfID uuid.UUID, tweetIDs []uint64) ([]*models.TweetWithUser, error)
+	GetTweetIDsByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]uint64, error)
+	GetHomeTweetIDs(ctx context.Context, userID uuid.UUID, limit int) ([]uint64, error)
+	GetPopularFollowingTweetIDs(ctx context.Context, userID uuid.UUID, minFollowers int64, beforeID *uint64, limit int) ([]uint64, error)
+	CreateMentions(ctx context.Context, tweetID uint64, userIDs []uuid.UUID) error
+	GetMentionsByTweetIDs(ctx context.Context, tweetIDs []uint64) ([]*models.Mention, error)
+	GetPollOptionsByTweetIDs(ctx context.Context, selfID uuid.UUID, tweetIDs []uint64) ([]*models.PollOption, error)
+	GetMediaByTweetIDs(ctx context.Context, tweetIDs []uint64) ([]*models.Media, error)
+	Vote(ctx context.Context, userID uuid.UUID, tweetID uint64, position int) error
+	GetMentionTweets(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	SearchTweets(ctx context.Context, selfID uuid.UUID, search *models.TweetSearch, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	// Update text of tweet and replace its mentions with mentionIDs in one transaction
+	Update(ctx context.Context, tweet *models.Tweet, mentionIDs []uuid.UUID, maxEdits int, editWindowSeconds int) (*models.Tweet, error)
+	GetTweetEdits(ctx context.Context, tweetID uint64) ([]*models.TweetEdit, error)
 	Delete(ctx context.Context, tweetID uint64) error
 }
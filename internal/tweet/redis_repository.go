@@ -0,0 +1,17 @@
+package tweet
+
+import (
+	"context"
+)
+
+// Tweet Redis repository interface
+type RedisRepository interface {
+	AddTimelineCtx(ctx context.Context, key string, maxSize int64, tweetIDs ...uint64) error
+	FanoutTimelineCtx(ctx context.Context, keys []string, maxSize int64, tweetID uint64) error
+	RemoveTimelineCtx(ctx context.Context, key string, tweetIDs ...uint64) error
+	RemoveFanoutTimelineCtx(ctx context.Context, keys []string, tweetID uint64) error
+	GetTimelineCtx(ctx context.Context, key string, beforeID *uint64, limit int) ([]uint64, error)
+	GetTimelineCountCtx(ctx context.Context, key string) (int64, error)
+	SetTimelineBuiltCtx(ctx context.Context, key string) error
+	GetTimelineBuiltCtx(ctx context.Context, key string) (bool, error)
+}
@@ -28,10 +28,72 @@ func (r *tweetRepo) Create(ctx context.Context, tweet *models.Tweet) (*models.Tw
 	defer span.End()
 
 	t := &models.Tweet{}
-	if err := r.db.QueryRowxContext(ctx, createTweetQuery, &tweet.UserID, &tweet.Text, &tweet.Image).StructScan(t); err != nil {
+	if len(tweet.PollOptions) == 0 && len(tweet.MediaIDs) == 0 {
+		if err := r.db.QueryRowxContext(ctx, createTweetQuery, &tweet.UserID, &tweet.Text, &tweet.Image).StructScan(t); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRepo.Create.StructScan")
+		}
+		return t, nil
+	}
+
+	// Tweet is created together with its poll and media
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.Create.BeginTxx")
+	}
+
+	if err = tx.QueryRowxContext(ctx, createTweetQuery, &tweet.UserID, &tweet.Text, &tweet.Image).StructScan(t); err != nil {
 		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
 		return nil, errors.Wrap(err, "tweetRepo.Create.StructScan")
 	}
+
+	if len(tweet.PollOptions) > 0 {
+		if _, err = tx.ExecContext(ctx, createPollQuery, t.ID, tweet.PollDuration); err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return nil, errors.Wrap(err, "tweetRepo.Create.ExecContext.createPollQuery")
+		}
+	}
+
+	for i, label := range tweet.PollOptions {
+		if _, err = tx.ExecContext(ctx, createPollOptionQuery, t.ID, i+1, label); err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return nil, errors.Wrap(err, "tweetRepo.Create.ExecContext.createPollOptionQuery")
+		}
+	}
+
+	// Only unattached media uploaded by the author can be attached
+	for i, mediaID := range tweet.MediaIDs {
+		result, err := tx.ExecContext(ctx, attachMediaQuery, t.ID, i+1, mediaID, &tweet.UserID)
+		if err == nil {
+			var rowsAffected int64
+			if rowsAffected, err = result.RowsAffected(); err == nil && rowsAffected == 0 {
+				err = sql.ErrNoRows
+			}
+		}
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return nil, errors.Wrap(err, "tweetRepo.Create.ExecContext.attachMediaQuery")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.Create.Commit")
+	}
+
 	return t, nil
 }
 
@@ -58,6 +120,74 @@ func (r *tweetRepo) CreateReply(ctx context.Context, tweetID uint64, replyID uin
 	return nil
 }
 
+func (r *tweetRepo) CreateQuote(ctx context.Context, tweetID uint64, quoteID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.CreateQuote", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, createQuoteQuery, tweetID, quoteID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRepo.CreateQuote.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "tweetRepo.CreateQuote.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "tweetRepo.CreateQuote.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *tweetRepo) Retweet(ctx context.Context, userID uuid.UUID, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.Retweet", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, retweetQuery, userID.String(), tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "tweetRepo.Retweet.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "tweetRepo.Retweet.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "tweetRepo.Retweet.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *tweetRepo) DeleteRetweet(ctx context.Context, userID uuid.UUID, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.DeleteRetweet", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteRetweetQuery, userID.String(), tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "tweetRepo.DeleteRetweet.ExecContext")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRepo.DeleteRetweet.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "tweetRepo.DeleteRetweet.rowsAffected")
+	}
+
+	return nil
+}
+
 func (r *tweetRepo) CheckTweetExist(ctx context.Context, tweetID uint64) error {
 	ctx, span := tracer.NewSpan(ctx, "tweetRepo.CheckTweetExist", nil)
 	defer span.End()
@@ -75,6 +205,19 @@ func (r *tweetRepo) CheckTweetExist(ctx context.Context, tweetID uint64) error {
 	return nil
 }
 
+// Get tweet regardless of who can see it
+func (r *tweetRepo) GetStoredTweet(ctx context.Context, tweetID uint64) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetStoredTweet", nil)
+	defer span.End()
+
+	t := &models.Tweet{}
+	if err := r.db.GetContext(ctx, t, getStoredTweetQuery, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetStoredTweet.GetContext")
+	}
+	return t, nil
+}
+
 func (r *tweetRepo) GetTweetByID(ctx context.Context, selfID uuid.UUID, tweetID uint64) (*models.TweetWithUser, error) {
 	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetTweetByID", nil)
 	defer span.End()
@@ -92,28 +235,34 @@ func (r *tweetRepo) GetTweets(ctx context.Context, selfID uuid.UUID, pq *utils.P
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotal); err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "tweetRepo.GetTweets.GetContext.getTotal")
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getTotal, selfID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRepo.GetTweets.GetContext.getTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
 	}
 
-	if totalCount == 0 {
-		return &models.TweetsList{
-			TotalCount: totalCount,
-			TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
-			Page:       pq.GetPage(),
-			Size:       pq.GetSize(),
-			HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
-			Tweets:     make([]*models.TweetWithUser, 0),
-		}, nil
-	}
-
-	var tweets = make([]*models.TweetWithUser, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &tweets, getTweets, selfID.String(), pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getTweets, selfID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "tweetRepo.GetTweets.SelectContext")
 	}
 
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
 	return &models.TweetsList{
 		TotalCount: totalCount,
 		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
@@ -129,9 +278,108 @@ func (r *tweetRepo) GetTweetsByUserID(ctx context.Context, selfID uuid.UUID, use
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getTotalByUserID, userID); err != nil {
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getTotalByUserID, selfID.String(), userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRepo.GetTweetsByUserID.GetContext.getTotalByUserID")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getTweetsByUserID, selfID.String(), userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "tweetRepo.GetTweetsByUserID.GetContext.getTotalByUserID")
+		return nil, errors.Wrap(err, "tweetRepo.GetTweetsByUserID.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
+
+func (r *tweetRepo) GetReplyTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetReplyTweets", nil)
+	defer span.End()
+
+	var totalCount int
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getReplysTotal, selfID.String(), tweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRepo.GetReplyTweets.SelectContext.getReplysTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getReplyTweetsByID, selfID.String(), tweetID, pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetReplyTweets.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
+
+func (r *tweetRepo) GetAncestorTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, maxDepth int) ([]*models.TweetWithUser, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetAncestorTweets", nil)
+	defer span.End()
+
+	var tweets = make([]*models.TweetWithUser, 0)
+	if err := r.db.SelectContext(ctx, &tweets, getAncestorTweets, selfID.String(), tweetID, maxDepth); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetAncestorTweets.SelectContext")
+	}
+
+	return tweets, nil
+}
+
+func (r *tweetRepo) GetDescendantTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, maxDepth int, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetDescendantTweets", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getDescendantsTotal, tweetID, maxDepth, selfID.String()); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetDescendantTweets.GetContext.getDescendantsTotal")
 	}
 
 	if totalCount == 0 {
@@ -146,9 +394,9 @@ func (r *tweetRepo) GetTweetsByUserID(ctx context.Context, selfID uuid.UUID, use
 	}
 
 	var tweets = make([]*models.TweetWithUser, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &tweets, getTweetsByUserID, selfID.String(), userID.String(), pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	if err := r.db.SelectContext(ctx, &tweets, getDescendantTweets, selfID.String(), tweetID, maxDepth, pq.GetOffset(), pq.GetLimit()); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "tweetRepo.GetTweetsByUserID.SelectContext")
+		return nil, errors.Wrap(err, "tweetRepo.GetDescendantTweets.SelectContext")
 	}
 
 	return &models.TweetsList{
@@ -161,14 +409,255 @@ func (r *tweetRepo) GetTweetsByUserID(ctx context.Context, selfID uuid.UUID, use
 	}, nil
 }
 
-func (r *tweetRepo) GetReplyTweets(ctx context.Context, selfID uuid.UUID, tweetID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error) {
-	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetReplyTweets", nil)
+func (r *tweetRepo) GetTweetsByIDs(ctx context.Context, selfID uuid.UUID, tweetIDs []uint64) ([]*models.TweetWithUser, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetTweetsByIDs", nil)
+	defer span.End()
+
+	var tweets = make([]*models.TweetWithUser, 0, len(tweetIDs))
+	if len(tweetIDs) == 0 {
+		return tweets, nil
+	}
+
+	query, args, err := sqlx.In(getTweetsByIDs, selfID.String(), selfID.String(), selfID.String(), tweetIDs, selfID.String(), selfID.String(), selfID.String(), selfID.String(), selfID.String())
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetTweetsByIDs.sqlx.In")
+	}
+
+	if err := r.db.SelectContext(ctx, &tweets, r.db.Rebind(query), args...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetTweetsByIDs.SelectContext")
+	}
+
+	return tweets, nil
+}
+
+func (r *tweetRepo) GetTweetIDsByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]uint64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetTweetIDsByUserID", nil)
+	defer span.End()
+
+	var tweetIDs = make([]uint64, 0, limit)
+	if err := r.db.SelectContext(ctx, &tweetIDs, getTweetIDsByUserID, userID.String(), limit); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetTweetIDsByUserID.SelectContext")
+	}
+
+	return tweetIDs, nil
+}
+
+func (r *tweetRepo) GetHomeTweetIDs(ctx context.Context, userID uuid.UUID, limit int) ([]uint64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetHomeTweetIDs", nil)
+	defer span.End()
+
+	var tweetIDs = make([]uint64, 0, limit)
+	if err := r.db.SelectContext(ctx, &tweetIDs, getHomeTweetIDs, userID.String(), limit); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetHomeTweetIDs.SelectContext")
+	}
+
+	return tweetIDs, nil
+}
+
+// Get ids of tweets older than beforeID by followed users with at least minFollowers followers, newest first
+func (r *tweetRepo) GetPopularFollowingTweetIDs(ctx context.Context, userID uuid.UUID, minFollowers int64, beforeID *uint64, limit int) ([]uint64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetPopularFollowingTweetIDs", nil)
+	defer span.End()
+
+	var tweetIDs = make([]uint64, 0, limit)
+	if err := r.db.SelectContext(ctx, &tweetIDs, getPopularFollowingTweetIDs, userID.String(), minFollowers, beforeID, limit); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetPopularFollowingTweetIDs.SelectContext")
+	}
+
+	return tweetIDs, nil
+}
+
+func (r *tweetRepo) CreateMentions(ctx context.Context, tweetID uint64, userIDs []uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.CreateMentions", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRepo.CreateMentions.BeginTxx")
+	}
+
+	for _, userID := range userIDs {
+		if _, err = tx.ExecContext(ctx, createMentionQuery, tweetID, userID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			if rbErr := tx.Rollback(); rbErr != nil {
+				tracer.AddSpanError(span, rbErr)
+			}
+			return errors.Wrap(err, "tweetRepo.CreateMentions.ExecContext")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRepo.CreateMentions.Commit")
+	}
+
+	return nil
+}
+
+func (r *tweetRepo) GetMentionsByTweetIDs(ctx context.Context, tweetIDs []uint64) ([]*models.Mention, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetMentionsByTweetIDs", nil)
+	defer span.End()
+
+	var mentions = make([]*models.Mention, 0)
+	if len(tweetIDs) == 0 {
+		return mentions, nil
+	}
+
+	query, args, err := sqlx.In(getMentionsByTweetIDs, tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetMentionsByTweetIDs.sqlx.In")
+	}
+
+	if err := r.db.SelectContext(ctx, &mentions, r.db.Rebind(query), args...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetMentionsByTweetIDs.SelectContext")
+	}
+
+	return mentions, nil
+}
+
+// Get poll options of tweets with vote counts and the vote of user
+func (r *tweetRepo) GetPollOptionsByTweetIDs(ctx context.Context, selfID uuid.UUID, tweetIDs []uint64) ([]*models.PollOption, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetPollOptionsByTweetIDs", nil)
+	defer span.End()
+
+	var options = make([]*models.PollOption, 0)
+	if len(tweetIDs) == 0 {
+		return options, nil
+	}
+
+	query, args, err := sqlx.In(getPollOptionsByTweetIDs, selfID.String(), tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetPollOptionsByTweetIDs.sqlx.In")
+	}
+
+	if err := r.db.SelectContext(ctx, &options, r.db.Rebind(query), args...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetPollOptionsByTweetIDs.SelectContext")
+	}
+
+	return options, nil
+}
+
+// Get media of tweets ordered by position
+func (r *tweetRepo) GetMediaByTweetIDs(ctx context.Context, tweetIDs []uint64) ([]*models.Media, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetMediaByTweetIDs", nil)
+	defer span.End()
+
+	var media = make([]*models.Media, 0)
+	if len(tweetIDs) == 0 {
+		return media, nil
+	}
+
+	query, args, err := sqlx.In(getMediaByTweetIDs, tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetMediaByTweetIDs.sqlx.In")
+	}
+
+	if err := r.db.SelectContext(ctx, &media, r.db.Rebind(query), args...); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetMediaByTweetIDs.SelectContext")
+	}
+
+	return media, nil
+}
+
+// Vote for poll option, a user votes once and only while the poll is open
+func (r *tweetRepo) Vote(ctx context.Context, userID uuid.UUID, tweetID uint64, position int) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.Vote", nil)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, voteQuery, tweetID, userID, position)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRepo.Vote.ExecContext")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "tweetRepo.Vote.RowsAffected")
+	}
+	if rowsAffected == 0 {
+		tracer.AddSpanError(span, sql.ErrNoRows)
+		return errors.Wrap(sql.ErrNoRows, "tweetRepo.Vote.rowsAffected")
+	}
+
+	return nil
+}
+
+func (r *tweetRepo) GetMentionTweets(ctx context.Context, selfID uuid.UUID, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetMentionTweets", nil)
 	defer span.End()
 
 	var totalCount int
-	if err := r.db.GetContext(ctx, &totalCount, getReplysTotal, tweetID); err != nil {
+	if !pq.UseCursor {
+		if err := r.db.GetContext(ctx, &totalCount, getMentionsTotal, userID.String(), selfID.String()); err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRepo.GetMentionTweets.GetContext.getMentionsTotal")
+		}
+
+		if totalCount == 0 {
+			return &models.TweetsList{
+				TotalCount: totalCount,
+				TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+				Page:       pq.GetPage(),
+				Size:       pq.GetSize(),
+				HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+				Tweets:     make([]*models.TweetWithUser, 0),
+			}, nil
+		}
+	}
+
+	var tweets = make([]*models.TweetWithUser, 0, pq.GetCursorLimit())
+	if err := r.db.SelectContext(ctx, &tweets, getMentionTweets, selfID.String(), userID.String(), pq.GetCursorKey(), pq.GetCursorID(), pq.GetOffset(), pq.GetCursorLimit()); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "tweetRepo.GetReplyTweets.SelectContext.getReplysTotal")
+		return nil, errors.Wrap(err, "tweetRepo.GetMentionTweets.SelectContext")
+	}
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	return &models.TweetsList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tweets:     tweets,
+	}, nil
+}
+
+// Full text search tweets, most relevant first
+func (r *tweetRepo) SearchTweets(ctx context.Context, selfID uuid.UUID, search *models.TweetSearch, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.SearchTweets", nil)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(
+		ctx,
+		&totalCount,
+		searchTweetsTotal,
+		search.Text,
+		search.FromUserName,
+		search.HasImage,
+		search.Since,
+		search.Until,
+		search.MinLikes,
+		selfID.String(),
+	); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.SearchTweets.GetContext.searchTweetsTotal")
 	}
 
 	if totalCount == 0 {
@@ -183,9 +672,22 @@ func (r *tweetRepo) GetReplyTweets(ctx context.Context, selfID uuid.UUID, tweetI
 	}
 
 	var tweets = make([]*models.TweetWithUser, 0, pq.GetSize())
-	if err := r.db.SelectContext(ctx, &tweets, getReplyTweetsByID, selfID.String(), tweetID, pq.GetOrderBy(), pq.GetOffset(), pq.GetLimit()); err != nil {
+	if err := r.db.SelectContext(
+		ctx,
+		&tweets,
+		searchTweets,
+		selfID.String(),
+		search.Text,
+		search.FromUserName,
+		search.HasImage,
+		search.Since,
+		search.Until,
+		search.MinLikes,
+		pq.GetOffset(),
+		pq.GetLimit(),
+	); err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, errors.Wrap(err, "tweetRepo.GetReplyTweets.SelectContext")
+		return nil, errors.Wrap(err, "tweetRepo.SearchTweets.SelectContext")
 	}
 
 	return &models.TweetsList{
@@ -198,6 +700,83 @@ func (r *tweetRepo) GetReplyTweets(ctx context.Context, selfID uuid.UUID, tweetI
 	}, nil
 }
 
+// Update tweet text while it is inside the edit window, storing the previous version as an edit
+func (r *tweetRepo) Update(ctx context.Context, tweet *models.Tweet, mentionIDs []uuid.UUID, maxEdits int, editWindowSeconds int) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.Update", nil)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.Update.BeginTxx")
+	}
+
+	t := &models.Tweet{}
+	if err = tx.QueryRowxContext(ctx, updateTweetQuery, tweet.ID, &tweet.Text, maxEdits, editWindowSeconds).StructScan(t); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.Wrap(err, "tweetRepo.Update.StructScan")
+	}
+
+	if err = r.replaceMentions(ctx, tx, tweet.ID, mentionIDs); err != nil {
+		tracer.AddSpanError(span, err)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			tracer.AddSpanError(span, rbErr)
+		}
+		return nil, errors.WithMessage(err, "tweetRepo.Update")
+	}
+
+	if err = tx.Commit(); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.Update.Commit")
+	}
+	return t, nil
+}
+
+// Delete mentions of tweet not in userIDs and add the new ones
+func (r *tweetRepo) replaceMentions(ctx context.Context, tx *sqlx.Tx, tweetID uint64, userIDs []uuid.UUID) error {
+	query, args := deleteMentionsQuery, []interface{}{tweetID}
+	if len(userIDs) > 0 {
+		ids := make([]string, 0, len(userIDs))
+		for _, userID := range userIDs {
+			ids = append(ids, userID.String())
+		}
+
+		var err error
+		query, args, err = sqlx.In(deleteRemovedMentionsQuery, tweetID, ids)
+		if err != nil {
+			return errors.Wrap(err, "replaceMentions.sqlx.In")
+		}
+		query = tx.Rebind(query)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "replaceMentions.ExecContext")
+	}
+
+	for _, userID := range userIDs {
+		if _, err := tx.ExecContext(ctx, createMentionQuery, tweetID, userID.String()); err != nil {
+			return errors.Wrap(err, "replaceMentions.ExecContext.createMentionQuery")
+		}
+	}
+	return nil
+}
+
+func (r *tweetRepo) GetTweetEdits(ctx context.Context, tweetID uint64) ([]*models.TweetEdit, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRepo.GetTweetEdits", nil)
+	defer span.End()
+
+	var edits = make([]*models.TweetEdit, 0)
+	if err := r.db.SelectContext(ctx, &edits, getTweetEditsQuery, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRepo.GetTweetEdits.SelectContext")
+	}
+
+	return edits, nil
+}
+
 func (r *tweetRepo) Delete(ctx context.Context, tweetID uint64) error {
 	ctx, span := tracer.NewSpan(ctx, "tweetRepo.Delete", nil)
 	defer span.End()
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/JamesHsu333/go-twitter/internal/tweet"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// Tweet redis repository
+type tweetRedisRepo struct {
+	redisClient *redis.Client
+}
+
+// Tweet redis repository constructor
+func NewTweetRedisRepo(redisClient *redis.Client) tweet.RedisRepository {
+	return &tweetRedisRepo{redisClient: redisClient}
+}
+
+// Add tweets to a timeline and trim it to max size
+func (a *tweetRedisRepo) AddTimelineCtx(ctx context.Context, key string, maxSize int64, tweetIDs ...uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.AddTimelineCtx", nil)
+	defer span.End()
+
+	if len(tweetIDs) == 0 {
+		return nil
+	}
+
+	members := make([]*redis.Z, 0, len(tweetIDs))
+	for _, id := range tweetIDs {
+		members = append(members, &redis.Z{Score: float64(id), Member: id})
+	}
+
+	pipe := a.redisClient.TxPipeline()
+	pipe.ZAdd(ctx, key, members...)
+	pipe.ZRemRangeByRank(ctx, key, 0, -maxSize-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRedisRepo.AddTimelineCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Push one tweet into many timelines
+func (a *tweetRedisRepo) FanoutTimelineCtx(ctx context.Context, keys []string, maxSize int64, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.FanoutTimelineCtx", nil)
+	defer span.End()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := a.redisClient.Pipeline()
+	for _, key := range keys {
+		pipe.ZAdd(ctx, key, &redis.Z{Score: float64(tweetID), Member: tweetID})
+		pipe.ZRemRangeByRank(ctx, key, 0, -maxSize-1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRedisRepo.FanoutTimelineCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Remove tweets from a timeline
+func (a *tweetRedisRepo) RemoveTimelineCtx(ctx context.Context, key string, tweetIDs ...uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.RemoveTimelineCtx", nil)
+	defer span.End()
+
+	if len(tweetIDs) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, 0, len(tweetIDs))
+	for _, id := range tweetIDs {
+		members = append(members, id)
+	}
+
+	if err := a.redisClient.ZRem(ctx, key, members...).Err(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRedisRepo.RemoveTimelineCtx.redisClient.ZRem")
+	}
+	return nil
+}
+
+// Remove one tweet from many timelines
+func (a *tweetRedisRepo) RemoveFanoutTimelineCtx(ctx context.Context, keys []string, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.RemoveFanoutTimelineCtx", nil)
+	defer span.End()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := a.redisClient.Pipeline()
+	for _, key := range keys {
+		pipe.ZRem(ctx, key, tweetID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRedisRepo.RemoveFanoutTimelineCtx.pipe.Exec")
+	}
+	return nil
+}
+
+// Get tweet ids of a timeline older than beforeID, newest first, nil beforeID starts from the newest
+func (a *tweetRedisRepo) GetTimelineCtx(ctx context.Context, key string, beforeID *uint64, limit int) ([]uint64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.GetTimelineCtx", nil)
+	defer span.End()
+
+	max := "+inf"
+	if beforeID != nil {
+		max = "(" + strconv.FormatUint(*beforeID, 10)
+	}
+
+	members, err := a.redisClient.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{Min: "-inf", Max: max, Count: int64(limit)}).Result()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "tweetRedisRepo.GetTimelineCtx.redisClient.ZRevRangeByScore")
+	}
+
+	tweetIDs := make([]uint64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, errors.Wrap(err, "tweetRedisRepo.GetTimelineCtx.strconv.ParseUint")
+		}
+		tweetIDs = append(tweetIDs, id)
+	}
+	return tweetIDs, nil
+}
+
+// Get number of tweets in a timeline
+func (a *tweetRedisRepo) GetTimelineCountCtx(ctx context.Context, key string) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.GetTimelineCountCtx", nil)
+	defer span.End()
+
+	count, err := a.redisClient.ZCard(ctx, key).Result()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, errors.Wrap(err, "tweetRedisRepo.GetTimelineCountCtx.redisClient.ZCard")
+	}
+	return count, nil
+}
+
+// Mark a timeline as fully built from postgres
+func (a *tweetRedisRepo) SetTimelineBuiltCtx(ctx context.Context, key string) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.SetTimelineBuiltCtx", nil)
+	defer span.End()
+
+	if err := a.redisClient.Set(ctx, key, 1, 0).Err(); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.Wrap(err, "tweetRedisRepo.SetTimelineBuiltCtx.redisClient.Set")
+	}
+	return nil
+}
+
+// Check whether a timeline was fully built, fanouts and backfills alone leave it partial
+func (a *tweetRedisRepo) GetTimelineBuiltCtx(ctx context.Context, key string) (bool, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetRedisRepo.GetTimelineBuiltCtx", nil)
+	defer span.End()
+
+	exists, err := a.redisClient.Exists(ctx, key).Result()
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return false, errors.Wrap(err, "tweetRedisRepo.GetTimelineBuiltCtx.redisClient.Exists")
+	}
+	return exists > 0, nil
+}
@@ -5,70 +5,408 @@ const (
 						VALUES ($1, $2, $3, now())
 						RETURNING *`
 
-	createReplyQuery = `INSERT INTO tweets_replys (tweet_id, reply_id)
+	createPollQuery = `INSERT INTO polls (tweet_id, ends_at, created_at)
+					   VALUES ($1, now() + $2 * interval '1 minute', now())`
+
+	createPollOptionQuery = `INSERT INTO poll_options (tweet_id, position, label) VALUES ($1, $2, $3)`
+
+	attachMediaQuery = `UPDATE tweet_media SET tweet_id = $1, position = $2
+						WHERE id = $3 AND user_id = $4 AND tweet_id IS NULL`
+
+	createReplyQuery = `WITH __r AS
+							(INSERT INTO tweets_replys (tweet_id, reply_id)
+							VALUES ($1, $2)
+							RETURNING reply_id
+							)
+						UPDATE tweets
+						SET conversation_id = (SELECT COALESCE(p.conversation_id, p.id) FROM tweets p WHERE p.id = $1)
+						WHERE id = (SELECT reply_id FROM __r)`
+
+	createQuoteQuery = `INSERT INTO tweets_quotes (tweet_id, quote_id)
 						VALUES ($1, $2)`
 
+	retweetQuery = `INSERT INTO tweets_retweets (user_id, tweet_id, created_at)
+					VALUES ($1, $2, now())`
+
+	deleteRetweetQuery = `DELETE FROM tweets_retweets WHERE user_id = $1 AND tweet_id = $2`
+
 	checkTweetExist = `SELECT EXISTS (SELECT 1 FROM tweets WHERE id = $1)`
 
-	getTweetQuery = `SELECT t.id, t.text, t.image, t.created_at,
+	getStoredTweetQuery = `SELECT id, user_id, text, image, edited_at, edit_count, created_at FROM tweets WHERE id = $1`
+
+	getTweetQuery = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 					 u.user_id, u.name, u.user_name, u.about, u.avatar,
-					 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, 
-					 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked
+					 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+					 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+					 EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+					 EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+					 (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+					 (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+					 COALESCE(t.conversation_id, t.id) AS conversation_id
 					 FROM tweets t
 					 INNER JOIN users u ON t.user_id = u.user_id
 					 LEFT JOIN tweets_replys r ON t.id = r.tweet_id
-					 LEFT JOIN tweets_likes l ON t.id = l.tweet_id 
+					 LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+					 LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
 					 WHERE t.id = $2
-					 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at,
+					 AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+					 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 					 u.user_id, u.name, u.user_name, u.about, u.avatar`
 
-	getTotal = `SELECT COUNT(id) FROM tweets`
+	getTotal = `SELECT COUNT(t.id) FROM tweets t
+				INNER JOIN users u ON t.user_id = u.user_id
+				WHERE (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+				AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+				AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)`
 
-	getTweets = `SELECT t.id, t.text, t.image, t.created_at,
+	getTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 				 u.user_id, u.name, u.user_name, u.about, u.avatar,
-				 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, 
-				 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked
+				 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+				 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+				 EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+				 EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+				 (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+				 (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+				 COALESCE(t.conversation_id, t.id) AS conversation_id
 				 FROM tweets t
 				 INNER JOIN users u ON t.user_id = u.user_id
 				 LEFT JOIN tweets_replys r ON t.id = r.tweet_id
-				 LEFT JOIN tweets_likes l ON t.id = l.tweet_id 
-				 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at,
+				 LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+				 LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+				 WHERE ($2::text IS NULL OR (t.created_at, t.id) < ($2::text::timestamptz, $3::text::bigint))
+				 AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+				 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+				 AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+				 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 				 u.user_id, u.name, u.user_name, u.about, u.avatar
-				 ORDER BY COALESCE(NULLIF($2, '')::bigint, t.id) desc
-				 OFFSET $3 LIMIT $4`
+				 ORDER BY t.created_at desc, t.id desc
+				 OFFSET $4 LIMIT $5`
 
-	getTotalByUserID = `SELECT COUNT(id) FROM tweets WHERE user_id = $1`
+	getTotalByUserID = `SELECT COUNT(t.id)
+						FROM (SELECT id AS tweet_id FROM tweets WHERE user_id = $2
+						UNION ALL
+						SELECT tweet_id FROM tweets_retweets WHERE user_id = $2) __t
+						INNER JOIN tweets t ON t.id = __t.tweet_id
+						INNER JOIN users u ON t.user_id = u.user_id
+						WHERE (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						AND (u.user_id = $2 OR NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id))`
 
-	getTweetsByUserID = `SELECT t.id, t.text, t.image, t.created_at,
+	getTweetsByUserID = `WITH __t AS
+							(SELECT t.id AS tweet_id, t.created_at AS activity_at, NULL::uuid AS retweeted_by
+							FROM tweets t
+							WHERE t.user_id = $2
+							UNION ALL
+							SELECT trt.tweet_id, trt.created_at, trt.user_id
+							FROM tweets_retweets trt
+							WHERE trt.user_id = $2
+							)
+						 SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 						 u.user_id, u.name, u.user_name, u.about, u.avatar,
-						 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, 
-						 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked
-						 FROM tweets t
+						 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						 EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						 EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						 (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						 (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						 COALESCE(t.conversation_id, t.id) AS conversation_id,
+						 __t.retweeted_by, ru.user_name AS retweeted_by_user_name,
+						 CASE WHEN __t.retweeted_by IS NULL THEN NULL ELSE __t.activity_at END AS retweeted_at
+						 FROM __t
+						 INNER JOIN tweets t ON t.id = __t.tweet_id
 						 INNER JOIN users u ON t.user_id = u.user_id
+						 LEFT JOIN users ru ON ru.user_id = __t.retweeted_by
 						 LEFT JOIN tweets_replys r ON t.id = r.tweet_id
-						 LEFT JOIN tweets_likes l ON t.id = l.tweet_id 
-						 WHERE t.user_id = $2
-						 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at,
-						 u.user_id, u.name, u.user_name, u.about, u.avatar
-						 ORDER BY COALESCE(NULLIF($3, '')::bigint, t.id) desc
-						 OFFSET $4 LIMIT $5`
+						 LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						 LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						 WHERE ($3::text IS NULL OR (__t.activity_at, __t.tweet_id) < ($3::text::timestamptz, $4::text::bigint))
+						 AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						 AND (u.user_id = $2 OR NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id))
+						 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						 u.user_id, u.name, u.user_name, u.about, u.avatar,
+						 __t.retweeted_by, __t.activity_at, ru.user_name
+						 ORDER BY __t.activity_at desc, t.id desc
+						 OFFSET $5 LIMIT $6`
 
-	getReplysTotal = `SELECT COUNT(id) FROM tweets t WHERE t.id
-					  IN (SELECT reply_id FROM tweets_replys r WHERE r.tweet_id = $1)`
+	getReplysTotal = `SELECT COUNT(t.id) FROM tweets t
+					  INNER JOIN users u ON t.user_id = u.user_id
+					  WHERE t.id IN (SELECT reply_id FROM tweets_replys r WHERE r.tweet_id = $2)
+					  AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+					  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)`
 
-	getReplyTweetsByID = `SELECT t.id, t.text, t.image, t.created_at,
+	getReplyTweetsByID = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 						  u.user_id, u.name, u.user_name, u.about, u.avatar,
-						  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, 
-						  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked
+						  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						  EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						  EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						  (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						  (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						  COALESCE(t.conversation_id, t.id) AS conversation_id
 						  FROM tweets t
 						  INNER JOIN users u ON t.user_id = u.user_id
 						  LEFT JOIN tweets_replys r ON t.id = r.tweet_id
-						  LEFT JOIN tweets_likes l ON t.id = l.tweet_id 
+						  LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						  LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
 						  WHERE t.id IN (SELECT rr.reply_id FROM tweets_replys rr WHERE rr.tweet_id = $2)
-						  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at,
+						  AND ($3::text IS NULL OR (t.created_at, t.id) < ($3::text::timestamptz, $4::text::bigint))
+						  AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+						  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
 						  u.user_id, u.name, u.user_name, u.about, u.avatar
-						  ORDER BY COALESCE(NULLIF($3, '')::bigint, t.id) desc
-						  OFFSET $4 LIMIT $5`
+						  ORDER BY t.created_at desc, t.id desc
+						  OFFSET $5 LIMIT $6`
+
+	getAncestorTweets = `WITH RECURSIVE __a AS
+							(SELECT r.tweet_id AS id, 1 AS depth
+							FROM tweets_replys r
+							WHERE r.reply_id = $2
+							UNION ALL
+							SELECT r.tweet_id, __a.depth + 1
+							FROM tweets_replys r
+							INNER JOIN __a ON r.reply_id = __a.id
+							WHERE __a.depth < $3
+							)
+						 SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						 u.user_id, u.name, u.user_name, u.about, u.avatar,
+						 COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						 EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						 EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						 EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						 (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						 (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						 COALESCE(t.conversation_id, t.id) AS conversation_id
+						 FROM __a
+						 INNER JOIN tweets t ON t.id = __a.id
+						 INNER JOIN users u ON t.user_id = u.user_id
+						 LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+						 LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						 LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						 WHERE (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						 GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						 u.user_id, u.name, u.user_name, u.about, u.avatar, __a.depth
+						 ORDER BY __a.depth desc`
+
+	getDescendantsTotal = `WITH RECURSIVE __d AS
+							(SELECT r.reply_id AS id, 1 AS depth
+							FROM tweets_replys r
+							WHERE r.tweet_id = $1
+							UNION ALL
+							SELECT r.reply_id, __d.depth + 1
+							FROM tweets_replys r
+							INNER JOIN __d ON r.tweet_id = __d.id
+							WHERE __d.depth < $2
+							)
+						   SELECT COUNT(__d.id) FROM __d
+						   INNER JOIN tweets t ON t.id = __d.id
+						   INNER JOIN users u ON t.user_id = u.user_id
+						   WHERE (NOT u.is_private OR u.user_id = $3 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $3 AND vf.following_id = u.user_id))
+						   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $3 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $3))
+						   AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $3 AND mu.muted_id = u.user_id)`
+
+	getDescendantTweets = `WITH RECURSIVE __d AS
+							(SELECT r.reply_id AS id, 1 AS depth, ARRAY[r.reply_id] AS path
+							FROM tweets_replys r
+							WHERE r.tweet_id = $2
+							UNION ALL
+							SELECT r.reply_id, __d.depth + 1, __d.path || r.reply_id
+							FROM tweets_replys r
+							INNER JOIN __d ON r.tweet_id = __d.id
+							WHERE __d.depth < $3
+							)
+						   SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						   u.user_id, u.name, u.user_name, u.about, u.avatar,
+						   COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						   EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						   EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						   EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						   (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						   (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						   COALESCE(t.conversation_id, t.id) AS conversation_id,
+						   __d.depth
+						   FROM __d
+						   INNER JOIN tweets t ON t.id = __d.id
+						   INNER JOIN users u ON t.user_id = u.user_id
+						   LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+						   LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						   LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						   WHERE (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						   AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						   AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+						   GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						   u.user_id, u.name, u.user_name, u.about, u.avatar, __d.depth, __d.path
+						   ORDER BY __d.path
+						   OFFSET $4 LIMIT $5`
+
+	getTweetsByIDs = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					  u.user_id, u.name, u.user_name, u.about, u.avatar,
+					  COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+					  EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = ? ) AS already_liked,
+					  EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = ? ) AS already_retweeted,
+					  EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = ? ) AS already_bookmarked,
+					  (SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+					  (SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+					  COALESCE(t.conversation_id, t.id) AS conversation_id
+					  FROM tweets t
+					  INNER JOIN users u ON t.user_id = u.user_id
+					  LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+					  LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+					  LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+					  WHERE t.id IN (?)
+					  AND (NOT u.is_private OR u.user_id = ? OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = ? AND vf.following_id = u.user_id))
+					  AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = ? AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = ?))
+					  AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = ? AND mu.muted_id = u.user_id)
+					  GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					  u.user_id, u.name, u.user_name, u.about, u.avatar
+					  ORDER BY t.id desc`
+
+	getTweetIDsByUserID = `SELECT id FROM tweets WHERE user_id = $1 ORDER BY id desc LIMIT $2`
+
+	getHomeTweetIDs = `SELECT t.id FROM tweets t
+					   WHERE t.user_id = $1
+					   OR t.user_id IN (SELECT f.following_id FROM follows f WHERE f.follower_id = $1)
+					   ORDER BY t.id desc LIMIT $2`
+
+	getPopularFollowingTweetIDs = `SELECT t.id FROM tweets t
+								   WHERE t.user_id IN (SELECT f.following_id FROM follows f WHERE f.follower_id = $1
+								   AND (SELECT COUNT(ff.follower_id) FROM follows ff WHERE ff.following_id = f.following_id) >= $2)
+								   AND ($3::bigint IS NULL OR t.id < $3)
+								   ORDER BY t.id desc LIMIT $4`
+
+	createMentionQuery = `INSERT INTO tweet_mentions (tweet_id, user_id)
+						  VALUES ($1, $2)
+						  ON CONFLICT DO NOTHING`
+
+	deleteMentionsQuery = `DELETE FROM tweet_mentions WHERE tweet_id = $1`
+
+	deleteRemovedMentionsQuery = `DELETE FROM tweet_mentions WHERE tweet_id = ? AND user_id NOT IN (?)`
+
+	getMentionsByTweetIDs = `SELECT m.tweet_id, m.user_id, u.user_name
+							 FROM tweet_mentions m
+							 INNER JOIN users u ON m.user_id = u.user_id
+							 WHERE m.tweet_id IN (?)`
+
+	getPollOptionsByTweetIDs = `SELECT o.tweet_id, p.ends_at, o.position, o.label,
+								(SELECT COUNT(v.user_id) FROM poll_votes v WHERE v.tweet_id = o.tweet_id AND v.position = o.position) AS votes,
+								(SELECT sv.position FROM poll_votes sv WHERE sv.tweet_id = o.tweet_id AND sv.user_id = ?) AS voted_position
+								FROM poll_options o
+								INNER JOIN polls p ON p.tweet_id = o.tweet_id
+								WHERE o.tweet_id IN (?)
+								ORDER BY o.tweet_id, o.position`
+
+	getMediaByTweetIDs = `SELECT * FROM tweet_media
+						  WHERE tweet_id IN (?)
+						  ORDER BY tweet_id, position`
+
+	voteQuery = `INSERT INTO poll_votes (tweet_id, user_id, position, created_at)
+				 SELECT o.tweet_id, $2, o.position, now()
+				 FROM poll_options o
+				 INNER JOIN polls p ON p.tweet_id = o.tweet_id
+				 WHERE o.tweet_id = $1 AND o.position = $3 AND p.ends_at > now()
+				 ON CONFLICT DO NOTHING`
+
+	getMentionsTotal = `SELECT COUNT(m.tweet_id) FROM tweet_mentions m
+						INNER JOIN tweets t ON t.id = m.tweet_id
+						INNER JOIN users u ON t.user_id = u.user_id
+						WHERE m.user_id = $1
+						AND (NOT u.is_private OR u.user_id = $2 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $2 AND vf.following_id = u.user_id))
+						AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $2 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $2))
+						AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $2 AND mu.muted_id = u.user_id)`
+
+	getMentionTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						u.user_id, u.name, u.user_name, u.about, u.avatar,
+						COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+						EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+						EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+						EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+						(SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+						(SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+						COALESCE(t.conversation_id, t.id) AS conversation_id
+						FROM tweets t
+						INNER JOIN users u ON t.user_id = u.user_id
+						LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+						LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+						LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+						WHERE t.id IN (SELECT m.tweet_id FROM tweet_mentions m WHERE m.user_id = $2)
+						AND ($3::text IS NULL OR (t.created_at, t.id) < ($3::text::timestamptz, $4::text::bigint))
+						AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+						AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+						AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+						GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+						u.user_id, u.name, u.user_name, u.about, u.avatar
+						ORDER BY t.created_at desc, t.id desc
+						OFFSET $5 LIMIT $6`
+
+	searchTweetsTotal = `SELECT COUNT(t.id) FROM tweets t
+						 INNER JOIN users u ON t.user_id = u.user_id
+						 WHERE ($1 = '' OR to_tsvector('english', t.text) @@ websearch_to_tsquery('english', $1))
+						 AND ($2::varchar IS NULL OR lower(u.user_name) = lower($2))
+						 AND ($3::boolean IS NULL OR (t.image IS NOT NULL OR EXISTS (SELECT 1 FROM tweet_media m WHERE m.tweet_id = t.id)) = $3)
+						 AND ($4::timestamptz IS NULL OR t.created_at >= $4)
+						 AND ($5::timestamptz IS NULL OR t.created_at < $5)
+						 AND ($6::bigint = 0 OR (SELECT COUNT(sl.user_id) FROM tweets_likes sl WHERE sl.tweet_id = t.id) >= $6)
+						 AND (NOT u.is_private OR u.user_id = $7 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $7 AND vf.following_id = u.user_id))
+						 AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $7 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $7))
+						 AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $7 AND mu.muted_id = u.user_id)`
+
+	searchTweets = `SELECT t.id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					u.user_id, u.name, u.user_name, u.about, u.avatar,
+					COUNT(distinct r.reply_id) AS replys, COUNT(distinct l.user_id) AS likes, COUNT(distinct rt.user_id) AS retweets,
+					EXISTS (SELECT 1 FROM tweets_likes tl WHERE tl.tweet_id = t.id AND tl.user_id = $1 ) AS already_liked,
+					EXISTS (SELECT 1 FROM tweets_retweets trt WHERE trt.tweet_id = t.id AND trt.user_id = $1 ) AS already_retweeted,
+					EXISTS (SELECT 1 FROM tweets_bookmarks tb WHERE tb.tweet_id = t.id AND tb.user_id = $1 ) AS already_bookmarked,
+					(SELECT q.quote_id FROM tweets_quotes q WHERE q.tweet_id = t.id) AS quote_id,
+					(SELECT rp.tweet_id FROM tweets_replys rp WHERE rp.reply_id = t.id) AS in_reply_to_id,
+					COALESCE(t.conversation_id, t.id) AS conversation_id,
+					CASE WHEN $2 = '' THEN NULL
+					ELSE ts_headline('english', t.text, websearch_to_tsquery('english', $2), 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')
+					END AS highlight
+					FROM tweets t
+					INNER JOIN users u ON t.user_id = u.user_id
+					LEFT JOIN tweets_replys r ON t.id = r.tweet_id
+					LEFT JOIN tweets_likes l ON t.id = l.tweet_id
+					LEFT JOIN tweets_retweets rt ON t.id = rt.tweet_id
+					WHERE ($2 = '' OR to_tsvector('english', t.text) @@ websearch_to_tsquery('english', $2))
+					AND ($3::varchar IS NULL OR lower(u.user_name) = lower($3))
+					AND ($4::boolean IS NULL OR (t.image IS NOT NULL OR EXISTS (SELECT 1 FROM tweet_media m WHERE m.tweet_id = t.id)) = $4)
+					AND ($5::timestamptz IS NULL OR t.created_at >= $5)
+					AND ($6::timestamptz IS NULL OR t.created_at < $6)
+					AND ($7::bigint = 0 OR (SELECT COUNT(sl.user_id) FROM tweets_likes sl WHERE sl.tweet_id = t.id) >= $7)
+					AND (NOT u.is_private OR u.user_id = $1 OR EXISTS (SELECT 1 FROM follows vf WHERE vf.follower_id = $1 AND vf.following_id = u.user_id))
+					AND NOT EXISTS (SELECT 1 FROM blocks bl WHERE (bl.blocker_id = $1 AND bl.blocked_id = u.user_id) OR (bl.blocker_id = u.user_id AND bl.blocked_id = $1))
+					AND NOT EXISTS (SELECT 1 FROM mutes mu WHERE mu.muter_id = $1 AND mu.muted_id = u.user_id)
+					GROUP BY t.id, t.user_id, t.text, t.image, t.created_at, t.edited_at, t.edit_count,
+					u.user_id, u.name, u.user_name, u.about, u.avatar
+					ORDER BY CASE WHEN $2 = '' THEN 0
+					ELSE ts_rank_cd(to_tsvector('english', t.text), websearch_to_tsquery('english', $2))
+					END desc, t.id desc
+					OFFSET $8 LIMIT $9`
+
+	updateTweetQuery = `WITH __o AS
+							(SELECT id, text, image, COALESCE(edited_at, created_at) AS created_at
+							FROM tweets
+							WHERE id = $1 AND edit_count < $3 AND created_at > now() - $4 * interval '1 second'
+							FOR UPDATE
+							),
+						__e AS
+							(INSERT INTO tweet_edits (tweet_id, text, image, created_at)
+							SELECT id, text, image, created_at FROM __o
+							)
+						UPDATE tweets t
+						SET text = $2, edited_at = now(), edit_count = t.edit_count + 1
+						FROM __o
+						WHERE t.id = __o.id
+						RETURNING t.*`
+
+	getTweetEditsQuery = `SELECT id, tweet_id, text, image, created_at
+						  FROM tweet_edits
+						  WHERE tweet_id = $1
+						  ORDER BY id desc`
 
 	deleteTweetQuery = `DELETE FROM tweets WHERE id = $1`
 )
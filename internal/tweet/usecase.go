@@ -13,9 +13,20 @@ import (
 type UseCase interface {
 	Create(ctx context.Context, tweet *models.Tweet) (*models.Tweet, error)
 	CreateReply(ctx context.Context, tweetID uint64, tweet *models.Tweet) (*models.Tweet, error)
+	CreateQuote(ctx context.Context, quoteID uint64, tweet *models.Tweet) (*models.Tweet, error)
+	Retweet(ctx context.Context, tweetID uint64) error
+	DeleteRetweet(ctx context.Context, tweetID uint64) error
 	GetTweetByID(ctx context.Context, tweetID uint64) (*models.TweetWithUser, error)
+	GetStoredTweet(ctx context.Context, tweetID uint64) (*models.Tweet, error)
 	GetTweets(ctx context.Context, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetHomeTweets(ctx context.Context, pq *utils.PaginationQuery) (*models.TweetsList, error)
 	GetTweetsByUserID(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
 	GetReplyTweets(ctx context.Context, tweetID uint64, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetMentionTweets(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	SearchTweets(ctx context.Context, query string, pq *utils.PaginationQuery) (*models.TweetsList, error)
+	GetThread(ctx context.Context, tweetID uint64, depth int, pq *utils.PaginationQuery) (*models.TweetThread, error)
+	Vote(ctx context.Context, tweetID uint64, vote *models.PollVote) (*models.Poll, error)
+	Update(ctx context.Context, tweetID uint64, tweet *models.Tweet) (*models.Tweet, error)
+	GetHistory(ctx context.Context, tweetID uint64) (*models.TweetHistory, error)
 	Delete(ctx context.Context, tweetID uint64) error
 }
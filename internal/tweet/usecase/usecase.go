@@ -2,10 +2,22 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/follow"
+	"github.com/JamesHsu333/go-twitter/internal/hashtag"
 	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/notification"
+	"github.com/JamesHsu333/go-twitter/internal/stream"
 	"github.com/JamesHsu333/go-twitter/internal/tweet"
+	"github.com/JamesHsu333/go-twitter/internal/user"
 	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
 	"github.com/JamesHsu333/go-twitter/pkg/logger"
 	"github.com/JamesHsu333/go-twitter/pkg/tracer"
@@ -14,16 +26,45 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	basePrefix         = "api-twitter:"
+	defaultThreadDepth = 3
+	maxThreadDepth     = 10
+	maxAncestorDepth   = 100
+	maxMentions        = 10
+
+	// Minutes a poll stays open when no duration is given
+	defaultPollDuration = 24 * 60
+
+	maxSearchQueryLength = 512
+)
+
 // Tweet Usecase
 type tweetUC struct {
-	cfg       *config.Config
-	tweetRepo tweet.Repository
-	logger    logger.Logger
+	cfg            *config.Config
+	tweetRepo      tweet.Repository
+	tweetRedisRepo tweet.RedisRepository
+	followRepo     follow.Repository
+	userRepo       user.Repository
+	hashtagUC      hashtag.UseCase
+	notificationUC notification.UseCase
+	streamUC       stream.UseCase
+	logger         logger.Logger
 }
 
 // New Usecase
-func NewTweetUseCase(cfg *config.Config, tweetRepo tweet.Repository, logger logger.Logger) tweet.UseCase {
-	return &tweetUC{cfg: cfg, tweetRepo: tweetRepo, logger: logger}
+func NewTweetUseCase(cfg *config.Config, tweetRepo tweet.Repository, tweetRedisRepo tweet.RedisRepository, followRepo follow.Repository, userRepo user.Repository, hashtagUC hashtag.UseCase, notificationUC notification.UseCase, streamUC stream.UseCase, logger logger.Logger) tweet.UseCase {
+	return &tweetUC{
+		cfg:            cfg,
+		tweetRepo:      tweetRepo,
+		tweetRedisRepo: tweetRedisRepo,
+		followRepo:     followRepo,
+		userRepo:       userRepo,
+		hashtagUC:      hashtagUC,
+		notificationUC: notificationUC,
+		streamUC:       streamUC,
+		logger:         logger,
+	}
 }
 
 // Create new tweet
@@ -38,17 +79,26 @@ func (u *tweetUC) Create(ctx context.Context, tweet *models.Tweet) (*models.Twee
 	}
 
 	tweet.UserID = self.UserID
+	if len(tweet.PollOptions) > 0 && tweet.PollDuration == 0 {
+		tweet.PollDuration = defaultPollDuration
+	}
 	if err = utils.ValidateStruct(ctx, tweet); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Create.ValidateStruct"))
 	}
 
-	createdTweet, err := u.tweetRepo.Create(ctx, tweet)
+	createdTweet, err := u.createTweet(ctx, tweet)
 	if err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, err
 	}
 
+	if len(tweet.PollOptions) > 0 {
+		createdTweet.Poll = u.getPolls(ctx, self.UserID, createdTweet.ID)[createdTweet.ID]
+	}
+
+	u.publishTweet(ctx, createdTweet)
+
 	return createdTweet, nil
 }
 
@@ -56,24 +106,28 @@ func (u *tweetUC) CreateReply(ctx context.Context, tweetID uint64, tweet *models
 	ctx, span := tracer.NewSpan(ctx, "tweetUC.CreateReply", nil)
 	defer span.End()
 
-	if err := u.tweetRepo.CheckTweetExist(ctx, tweetID); err != nil {
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.CreateReply.CheckTweetExist"))
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.CreateReply.GetUserFromCtx"))
 	}
 
-	self, err := utils.GetUserFromCtx(ctx)
+	// Tweets hidden from the user by blocks or protection cannot be replied to
+	parent, err := u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID)
 	if err != nil {
 		tracer.AddSpanError(span, err)
-		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.CreateReply.GetUserFromCtx"))
+		return nil, httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.CreateReply.GetTweetByID"))
 	}
 
+	// Polls are only attached to standalone tweets
+	tweet.PollOptions = nil
 	tweet.UserID = self.UserID
 	if err = utils.ValidateStruct(ctx, tweet); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.CreateReply.ValidateStruct"))
 	}
 
-	createdTweet, err := u.tweetRepo.Create(ctx, tweet)
+	createdTweet, err := u.createTweet(ctx, tweet)
 	if err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, err
@@ -81,16 +135,101 @@ func (u *tweetUC) CreateReply(ctx context.Context, tweetID uint64, tweet *models
 
 	if err = u.tweetRepo.CreateReply(ctx, tweetID, createdTweet.ID); err != nil {
 		tracer.AddSpanError(span, err)
-		if err = u.tweetRepo.Delete(ctx, createdTweet.ID); err != nil {
-			tracer.AddSpanError(span, err)
-			return nil, err
+		if delErr := u.tweetRepo.Delete(ctx, createdTweet.ID); delErr != nil {
+			tracer.AddSpanError(span, delErr)
+			u.logger.Errorf("tweetUC.CreateReply.Delete: %v", delErr)
 		}
 		return nil, err
 	}
+	createdTweet.InReplyToID = &tweetID
+
+	u.publishTweet(ctx, createdTweet)
+
+	u.notify(ctx, &models.Notification{UserID: parent.UserID, ActorID: self.UserID, Type: models.NotificationReply, TweetID: &tweetID})
 
 	return createdTweet, nil
 }
 
+// Create new tweet quoting an existing tweet
+func (u *tweetUC) CreateQuote(ctx context.Context, quoteID uint64, tweet *models.Tweet) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.CreateQuote", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.CreateQuote.GetUserFromCtx"))
+	}
+
+	// Tweets hidden from the user by blocks or protection cannot be quoted
+	if _, err = u.tweetRepo.GetTweetByID(ctx, self.UserID, quoteID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.CreateQuote.GetTweetByID"))
+	}
+
+	// Polls are only attached to standalone tweets
+	tweet.PollOptions = nil
+	tweet.UserID = self.UserID
+	if err = utils.ValidateStruct(ctx, tweet); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.CreateQuote.ValidateStruct"))
+	}
+
+	createdTweet, err := u.createTweet(ctx, tweet)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if err = u.tweetRepo.CreateQuote(ctx, createdTweet.ID, quoteID); err != nil {
+		tracer.AddSpanError(span, err)
+		if delErr := u.tweetRepo.Delete(ctx, createdTweet.ID); delErr != nil {
+			tracer.AddSpanError(span, delErr)
+			u.logger.Errorf("tweetUC.CreateQuote.Delete: %v", delErr)
+		}
+		return nil, err
+	}
+	createdTweet.QuoteID = &quoteID
+
+	u.publishTweet(ctx, createdTweet)
+
+	return createdTweet, nil
+}
+
+// Retweet an existing tweet
+func (u *tweetUC) Retweet(ctx context.Context, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.Retweet", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.Retweet.GetUserFromCtx"))
+	}
+
+	// Tweets hidden from the user by blocks or protection cannot be retweeted
+	if _, err = u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.Retweet.GetTweetByID"))
+	}
+
+	return u.tweetRepo.Retweet(ctx, self.UserID, tweetID)
+}
+
+// Undo retweet
+func (u *tweetUC) DeleteRetweet(ctx context.Context, tweetID uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.DeleteRetweet", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.DeleteRetweet.GetUserFromCtx"))
+	}
+
+	return u.tweetRepo.DeleteRetweet(ctx, self.UserID, tweetID)
+}
+
 // Get tweet by id
 func (u *tweetUC) GetTweetByID(ctx context.Context, tweetID uint64) (*models.TweetWithUser, error) {
 	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetTweetByID", nil)
@@ -108,6 +247,29 @@ func (u *tweetUC) GetTweetByID(ctx context.Context, tweetID uint64) (*models.Twe
 		return nil, err
 	}
 
+	u.attachMentions(ctx, tweet)
+	u.attachPolls(ctx, self.UserID, tweet)
+	u.attachMedia(ctx, tweet)
+
+	return tweet, nil
+}
+
+// Get tweet with its media regardless of blocks and protection, for authorizing and cleaning up the tweet
+func (u *tweetUC) GetStoredTweet(ctx context.Context, tweetID uint64) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetStoredTweet", nil)
+	defer span.End()
+
+	tweet, err := u.tweetRepo.GetStoredTweet(ctx, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if tweet.Media, err = u.tweetRepo.GetMediaByTweetIDs(ctx, []uint64{tweetID}); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
 	return tweet, nil
 }
 
@@ -122,7 +284,137 @@ func (u *tweetUC) GetTweets(ctx context.Context, pq *utils.PaginationQuery) (*mo
 		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetTweets.GetUserFromCtx"))
 	}
 
-	return u.tweetRepo.GetTweets(ctx, self.UserID, pq)
+	tweetsList, err := u.tweetRepo.GetTweets(ctx, self.UserID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	u.attachMentions(ctx, tweetsList.Tweets...)
+	u.attachPolls(ctx, self.UserID, tweetsList.Tweets...)
+	u.attachMedia(ctx, tweetsList.Tweets...)
+
+	return tweetsList, nil
+}
+
+// Get home timeline of tweets from followed users
+func (u *tweetUC) GetHomeTweets(ctx context.Context, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetHomeTweets", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetHomeTweets.GetUserFromCtx"))
+	}
+
+	key := u.generateTimelineKey(self.UserID.String())
+
+	pushedCount, err := u.tweetRedisRepo.GetTimelineCountCtx(ctx, key)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	built, err := u.tweetRedisRepo.GetTimelineBuiltCtx(ctx, u.generateTimelineBuiltKey(self.UserID.String()))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	// Follow backfills and fanouts also seed expired or evicted timelines, so only a built one is complete
+	if !built || pushedCount == 0 {
+		pushedCount, err = u.rebuildTimeline(ctx, self.UserID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+	}
+
+	var beforeID *uint64
+	if cursorID := pq.GetCursorID(); cursorID != nil {
+		id, err := strconv.ParseUint(*cursorID, 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "tweetUC.GetHomeTweets.ParseUint"))
+		}
+		beforeID = &id
+	}
+
+	// One more tweet than the page tells whether there is a next page
+	tweets, err := u.getHomePage(ctx, self.UserID, key, beforeID, pq.GetOffset()+pq.GetLimit()+1)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	if pq.GetOffset() < len(tweets) {
+		tweets = tweets[pq.GetOffset():]
+	} else {
+		tweets = tweets[:0]
+	}
+
+	u.attachMentions(ctx, tweets...)
+	u.attachPolls(ctx, self.UserID, tweets...)
+	u.attachMedia(ctx, tweets...)
+
+	if pq.UseCursor {
+		return utils.GetTweetsCursorList(tweets, pq), nil
+	}
+
+	// Pushed and pulled tweets overlap and some are hidden from the user, so the home timeline has no total count
+	tweetsList := &models.TweetsList{Page: pq.GetPage(), Size: pq.GetSize(), Tweets: tweets}
+	if len(tweets) > pq.GetLimit() {
+		tweetsList.Tweets = tweets[:pq.GetLimit()]
+		tweetsList.HasMore = true
+	}
+	return tweetsList, nil
+}
+
+// Get up to limit tweets of home timeline older than beforeID, newest first.
+// Tweets hidden from the user are skipped, more ids are read until the page is full or both sources run out.
+func (u *tweetUC) getHomePage(ctx context.Context, userID uuid.UUID, key string, beforeID *uint64, limit int) ([]*models.TweetWithUser, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.getHomePage", nil)
+	defer span.End()
+
+	tweets := make([]*models.TweetWithUser, 0, limit)
+	for len(tweets) < limit {
+		want := limit - len(tweets)
+
+		pushedIDs, err := u.tweetRedisRepo.GetTimelineCtx(ctx, key, beforeID, want)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+
+		pulledIDs, err := u.tweetRepo.GetPopularFollowingTweetIDs(ctx, userID, u.cfg.Timeline.FanoutThreshold, beforeID, want)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+
+		// The newest ids of both sources together are the newest of the timeline
+		tweetIDs := mergeTweetIDs(pushedIDs, pulledIDs)
+		if len(tweetIDs) > want {
+			tweetIDs = tweetIDs[:want]
+		}
+		if len(tweetIDs) == 0 {
+			break
+		}
+
+		visible, err := u.tweetRepo.GetTweetsByIDs(ctx, userID, tweetIDs)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return nil, err
+		}
+		tweets = append(tweets, visible...)
+
+		if len(pushedIDs) < want && len(pulledIDs) < want {
+			break
+		}
+		beforeID = &tweetIDs[len(tweetIDs)-1]
+	}
+
+	return tweets, nil
 }
 
 // Get tweets by user id
@@ -136,7 +428,28 @@ func (u *tweetUC) GetTweetsByUserID(ctx context.Context, userID uuid.UUID, pq *u
 		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetTweetByUserID.GetUserFromCtx"))
 	}
 
-	return u.tweetRepo.GetTweetsByUserID(ctx, self.UserID, userID, pq)
+	canView, err := u.followRepo.CanViewTweets(ctx, self.UserID, userID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	if !canView {
+		err = errors.Errorf("tweets of %s are protected", userID)
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewForbiddenError(errors.WithMessage(err, "tweetUC.GetTweetsByUserID.CanViewTweets"))
+	}
+
+	tweetsList, err := u.tweetRepo.GetTweetsByUserID(ctx, self.UserID, userID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	u.attachMentions(ctx, tweetsList.Tweets...)
+	u.attachPolls(ctx, self.UserID, tweetsList.Tweets...)
+	u.attachMedia(ctx, tweetsList.Tweets...)
+
+	return tweetsList, nil
 }
 
 // Get reply tweets by tweet id
@@ -150,7 +463,260 @@ func (u *tweetUC) GetReplyTweets(ctx context.Context, tweetID uint64, pq *utils.
 		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetReplyTweets.GetUserFromCtx"))
 	}
 
-	return u.tweetRepo.GetReplyTweets(ctx, self.UserID, tweetID, pq)
+	// Replies of a protected tweet are hidden along with it
+	if _, err = u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	tweetsList, err := u.tweetRepo.GetReplyTweets(ctx, self.UserID, tweetID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	u.attachMentions(ctx, tweetsList.Tweets...)
+	u.attachPolls(ctx, self.UserID, tweetsList.Tweets...)
+	u.attachMedia(ctx, tweetsList.Tweets...)
+
+	return tweetsList, nil
+}
+
+// Get tweets mentioning a user
+func (u *tweetUC) GetMentionTweets(ctx context.Context, userID uuid.UUID, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetMentionTweets", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetMentionTweets.GetUserFromCtx"))
+	}
+
+	tweetsList, err := u.tweetRepo.GetMentionTweets(ctx, self.UserID, userID, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	u.attachMentions(ctx, tweetsList.Tweets...)
+	u.attachPolls(ctx, self.UserID, tweetsList.Tweets...)
+	u.attachMedia(ctx, tweetsList.Tweets...)
+
+	return tweetsList, nil
+}
+
+// Search tweets by text and operators, most relevant first
+func (u *tweetUC) SearchTweets(ctx context.Context, query string, pq *utils.PaginationQuery) (*models.TweetsList, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.SearchTweets", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.SearchTweets.GetUserFromCtx"))
+	}
+
+	if strings.TrimSpace(query) == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		err = errors.Errorf("search query must be between 1 and %d characters", maxSearchQueryLength)
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.SearchTweets"))
+	}
+
+	search, err := utils.ParseTweetSearch(query)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.SearchTweets.ParseTweetSearch"))
+	}
+
+	tweetsList, err := u.tweetRepo.SearchTweets(ctx, self.UserID, search, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	u.attachMentions(ctx, tweetsList.Tweets...)
+	u.attachPolls(ctx, self.UserID, tweetsList.Tweets...)
+	u.attachMedia(ctx, tweetsList.Tweets...)
+
+	return tweetsList, nil
+}
+
+// Get ancestor chain and reply tree of a tweet
+func (u *tweetUC) GetThread(ctx context.Context, tweetID uint64, depth int, pq *utils.PaginationQuery) (*models.TweetThread, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetThread", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.GetThread.GetUserFromCtx"))
+	}
+
+	if depth <= 0 {
+		depth = defaultThreadDepth
+	}
+	if depth > maxThreadDepth {
+		depth = maxThreadDepth
+	}
+
+	tweet, err := u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	ancestors, err := u.tweetRepo.GetAncestorTweets(ctx, self.UserID, tweetID, maxAncestorDepth)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	replies, err := u.tweetRepo.GetDescendantTweets(ctx, self.UserID, tweetID, depth, pq)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	threadTweets := append(append(ancestors, tweet), replies.Tweets...)
+	u.attachMentions(ctx, threadTweets...)
+	u.attachPolls(ctx, self.UserID, threadTweets...)
+	u.attachMedia(ctx, threadTweets...)
+
+	return &models.TweetThread{
+		Ancestors: ancestors,
+		Tweet:     tweet,
+		Replies:   replies,
+	}, nil
+}
+
+// Edit tweet text within the edit window, the route only lets the author through
+func (u *tweetUC) Update(ctx context.Context, tweetID uint64, tweet *models.Tweet) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.Update", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.Update.GetUserFromCtx"))
+	}
+
+	existing, err := u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	editWindow := time.Duration(u.cfg.Tweet.EditWindowSeconds) * time.Second
+	if time.Since(existing.CreatedAt) > editWindow {
+		err = errors.New("edit window has passed")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Update.EditWindow"))
+	}
+	if existing.EditCount >= u.cfg.Tweet.MaxEdits {
+		err = errors.New("maximum number of edits reached")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Update.MaxEdits"))
+	}
+
+	tweet.ID = tweetID
+	tweet.UserID = self.UserID
+	if err = utils.ValidateStruct(ctx, tweet); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Update.ValidateStruct"))
+	}
+
+	// Mentions are replaced along with the text, so users no longer mentioned drop the tweet from their mentions
+	mentionIDs, mentions, resolved := u.resolveMentions(ctx, tweet)
+
+	updatedTweet, err := u.tweetRepo.Update(ctx, tweet, mentionIDs, u.cfg.Tweet.MaxEdits, u.cfg.Tweet.EditWindowSeconds)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	if len(mentions) > 0 {
+		updatedTweet.Mentions = mentions
+	}
+	u.notifyMentions(ctx, updatedTweet, mentionIDs, resolved, existing.Text)
+	u.addEditedHashtags(ctx, updatedTweet, existing.Text)
+
+	return updatedTweet, nil
+}
+
+// Vote for an option of the poll of a tweet, returns the poll with its results
+func (u *tweetUC) Vote(ctx context.Context, tweetID uint64, vote *models.PollVote) (*models.Poll, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.Vote", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "tweetUC.Vote.GetUserFromCtx"))
+	}
+
+	if err = utils.ValidateStruct(ctx, vote); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Vote.ValidateStruct"))
+	}
+
+	// Tweets hidden from the user by blocks or protection cannot be voted on
+	if _, err = u.tweetRepo.GetTweetByID(ctx, self.UserID, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.Vote.GetTweetByID"))
+	}
+
+	poll, ok := u.getPolls(ctx, self.UserID, tweetID)[tweetID]
+	if !ok {
+		err = errors.Errorf("tweet %d has no poll", tweetID)
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewNotFoundError(errors.WithMessage(err, "tweetUC.Vote.getPolls"))
+	}
+	switch {
+	case poll.VotedOption != nil:
+		err = errors.New("user has already voted")
+	case poll.Closed:
+		err = errors.New("poll is closed")
+	case vote.Position > len(poll.Options):
+		err = errors.Errorf("poll has no option %d", vote.Position)
+	}
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Vote"))
+	}
+
+	if err = u.tweetRepo.Vote(ctx, self.UserID, tweetID, vote.Position); err != nil {
+		tracer.AddSpanError(span, err)
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.Vote.Vote"))
+		}
+		return nil, err
+	}
+
+	return u.getPolls(ctx, self.UserID, tweetID)[tweetID], nil
+}
+
+// Get tweet with its previous versions
+func (u *tweetUC) GetHistory(ctx context.Context, tweetID uint64) (*models.TweetHistory, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.GetHistory", nil)
+	defer span.End()
+
+	tweet, err := u.GetTweetByID(ctx, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	edits, err := u.tweetRepo.GetTweetEdits(ctx, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	return &models.TweetHistory{
+		Tweet: tweet,
+		Edits: edits,
+	}, nil
 }
 
 // Delete tweet
@@ -158,10 +724,411 @@ func (u *tweetUC) Delete(ctx context.Context, tweetID uint64) error {
 	ctx, span := tracer.NewSpan(ctx, "tweetUC.Delete", nil)
 	defer span.End()
 
-	if err := u.tweetRepo.Delete(ctx, tweetID); err != nil {
+	stored, err := u.tweetRepo.GetStoredTweet(ctx, tweetID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if err = u.tweetRepo.Delete(ctx, tweetID); err != nil {
 		tracer.AddSpanError(span, err)
 		return err
 	}
 
+	keys := u.getTimelineKeys(u.getTimelineUserIDs(ctx, stored.UserID))
+	if err = u.tweetRedisRepo.RemoveFanoutTimelineCtx(ctx, keys, tweetID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.Delete.RemoveFanoutTimelineCtx: %v", err)
+	}
+
 	return nil
 }
+
+// Run the side effects of a newly created tweet
+func (u *tweetUC) publishTweet(ctx context.Context, tweet *models.Tweet) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.publishTweet", nil)
+	defer span.End()
+
+	u.createMentions(ctx, tweet)
+	u.fanoutTweet(ctx, tweet)
+
+	if err := u.hashtagUC.AddTweetHashtags(ctx, tweet); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.publishTweet.AddTweetHashtags: %v", err)
+	}
+}
+
+// Resolve @user_name mentions of a new tweet, store and notify them
+func (u *tweetUC) createMentions(ctx context.Context, tweet *models.Tweet) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.createMentions", nil)
+	defer span.End()
+
+	userIDs, mentions, resolved := u.resolveMentions(ctx, tweet)
+	if len(userIDs) == 0 {
+		return
+	}
+
+	if err := u.tweetRepo.CreateMentions(ctx, tweet.ID, userIDs); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.createMentions.CreateMentions: %v", err)
+		return
+	}
+
+	tweet.Mentions = mentions
+	u.notifyMentions(ctx, tweet, userIDs, resolved, "")
+}
+
+// Resolve @user_name mentions of tweet, unknown user names are left as plain text.
+// Returns the distinct mentioned users, the mentions and the user id of every user name, uuid.Nil when unknown.
+func (u *tweetUC) resolveMentions(ctx context.Context, tweet *models.Tweet) ([]uuid.UUID, []*models.Mention, map[string]uuid.UUID) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.resolveMentions", nil)
+	defer span.End()
+
+	resolved := make(map[string]uuid.UUID)
+	userIDs := make([]uuid.UUID, 0)
+	mentions := make([]*models.Mention, 0)
+	for _, mention := range utils.ExtractMentions(tweet.Text) {
+		userID, ok := resolved[mention.UserName]
+		if !ok {
+			if len(resolved) >= maxMentions {
+				continue
+			}
+			mentioned, err := u.userRepo.GetByUserName(ctx, tweet.UserID, mention.UserName)
+			if err != nil {
+				if errors.Cause(err) != sql.ErrNoRows {
+					tracer.AddSpanError(span, err)
+					u.logger.Errorf("tweetUC.resolveMentions.GetByUserName: %v", err)
+				}
+				resolved[mention.UserName] = uuid.Nil
+				continue
+			}
+			userID = mentioned.UserID
+			resolved[mention.UserName] = userID
+			userIDs = append(userIDs, userID)
+		}
+		if userID == uuid.Nil {
+			continue
+		}
+		mention.TweetID = tweet.ID
+		mention.UserID = userID
+		mentions = append(mentions, mention)
+	}
+
+	return userIDs, mentions, resolved
+}
+
+// Notify mentioned users, users already mentioned in the previous text of an edited tweet are not notified again
+func (u *tweetUC) notifyMentions(ctx context.Context, tweet *models.Tweet, userIDs []uuid.UUID, resolved map[string]uuid.UUID, previousText string) {
+	notified := make(map[uuid.UUID]struct{})
+	for _, mention := range utils.ExtractMentions(previousText) {
+		if userID, ok := resolved[mention.UserName]; ok {
+			notified[userID] = struct{}{}
+		}
+	}
+
+	for _, userID := range userIDs {
+		if _, ok := notified[userID]; ok {
+			continue
+		}
+		u.notify(ctx, &models.Notification{UserID: userID, ActorID: tweet.UserID, Type: models.NotificationMention, TweetID: &tweet.ID})
+	}
+}
+
+// Create tweet attaching its media, media not uploaded by the author or already attached are rejected
+func (u *tweetUC) createTweet(ctx context.Context, tweet *models.Tweet) (*models.Tweet, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.createTweet", nil)
+	defer span.End()
+
+	if tweet.Image != nil && len(tweet.MediaIDs) > 0 {
+		err := errors.New("image and media cannot be attached together")
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "tweetUC.createTweet"))
+	}
+
+	createdTweet, err := u.tweetRepo.Create(ctx, tweet)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httpErrors.NewBadRequestError(errors.WithMessage(errors.New("media not found or already attached"), "tweetUC.createTweet.Create"))
+		}
+		return nil, err
+	}
+
+	if len(tweet.MediaIDs) > 0 {
+		if createdTweet.Media, err = u.tweetRepo.GetMediaByTweetIDs(ctx, []uint64{createdTweet.ID}); err != nil {
+			tracer.AddSpanError(span, err)
+			u.logger.Errorf("tweetUC.createTweet.GetMediaByTweetIDs: %v", err)
+		}
+		for _, m := range createdTweet.Media {
+			m.Renditions = utils.GetImageRenditions(m.URL)
+		}
+	}
+
+	return createdTweet, nil
+}
+
+// Fill media of tweets in position order
+func (u *tweetUC) attachMedia(ctx context.Context, tweets ...*models.TweetWithUser) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.attachMedia", nil)
+	defer span.End()
+
+	tweetIDs := make([]uint64, 0, len(tweets))
+	for _, t := range tweets {
+		tweetIDs = append(tweetIDs, t.ID)
+	}
+
+	media, err := u.tweetRepo.GetMediaByTweetIDs(ctx, tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.attachMedia.GetMediaByTweetIDs: %v", err)
+		return
+	}
+
+	byTweet := make(map[uint64][]*models.Media, len(tweets))
+	for _, m := range media {
+		m.Renditions = utils.GetImageRenditions(m.URL)
+		byTweet[*m.TweetID] = append(byTweet[*m.TweetID], m)
+	}
+	for _, t := range tweets {
+		t.Media = byTweet[t.ID]
+	}
+}
+
+// Fill polls of tweets with the results visible to user
+func (u *tweetUC) attachPolls(ctx context.Context, selfID uuid.UUID, tweets ...*models.TweetWithUser) {
+	tweetIDs := make([]uint64, 0, len(tweets))
+	for _, t := range tweets {
+		tweetIDs = append(tweetIDs, t.ID)
+	}
+
+	polls := u.getPolls(ctx, selfID, tweetIDs...)
+	for _, t := range tweets {
+		t.Poll = polls[t.ID]
+	}
+}
+
+// Get polls of tweets by tweet id.
+// Vote counts stay hidden until the user has voted or the poll is closed.
+func (u *tweetUC) getPolls(ctx context.Context, selfID uuid.UUID, tweetIDs ...uint64) map[uint64]*models.Poll {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.getPolls", nil)
+	defer span.End()
+
+	polls := make(map[uint64]*models.Poll)
+
+	options, err := u.tweetRepo.GetPollOptionsByTweetIDs(ctx, selfID, tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.getPolls.GetPollOptionsByTweetIDs: %v", err)
+		return polls
+	}
+
+	now := time.Now()
+	for _, o := range options {
+		poll, ok := polls[o.TweetID]
+		if !ok {
+			poll = &models.Poll{
+				EndsAt:      o.EndsAt,
+				Closed:      !o.EndsAt.After(now),
+				VotedOption: o.VotedPosition,
+				Options:     make([]*models.PollOption, 0),
+			}
+			polls[o.TweetID] = poll
+		}
+		poll.Options = append(poll.Options, o)
+	}
+
+	for _, poll := range polls {
+		if poll.VotedOption == nil && !poll.Closed {
+			for _, o := range poll.Options {
+				o.Votes = nil
+			}
+			continue
+		}
+		var total int64
+		for _, o := range poll.Options {
+			if o.Votes != nil {
+				total += *o.Votes
+			}
+		}
+		poll.TotalVotes = &total
+	}
+
+	return polls
+}
+
+// Add hashtags introduced by an edit, so trends only count each tag of a tweet once
+func (u *tweetUC) addEditedHashtags(ctx context.Context, tweet *models.Tweet, previousText string) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.addEditedHashtags", nil)
+	defer span.End()
+
+	previous := make(map[string]struct{})
+	for _, tag := range utils.ExtractHashtags(previousText) {
+		previous[tag] = struct{}{}
+	}
+
+	added := make([]string, 0)
+	for _, tag := range utils.ExtractHashtags(tweet.Text) {
+		if _, ok := previous[tag]; !ok {
+			added = append(added, "#"+tag)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	if err := u.hashtagUC.AddTweetHashtags(ctx, &models.Tweet{ID: tweet.ID, Text: strings.Join(added, " ")}); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.addEditedHashtags.AddTweetHashtags: %v", err)
+	}
+}
+
+// Fill mention entities of tweets, matching stored mentions against the text
+func (u *tweetUC) attachMentions(ctx context.Context, tweets ...*models.TweetWithUser) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.attachMentions", nil)
+	defer span.End()
+
+	tweetIDs := make([]uint64, 0, len(tweets))
+	for _, t := range tweets {
+		tweetIDs = append(tweetIDs, t.ID)
+	}
+
+	stored, err := u.tweetRepo.GetMentionsByTweetIDs(ctx, tweetIDs)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.attachMentions.GetMentionsByTweetIDs: %v", err)
+		return
+	}
+	if len(stored) == 0 {
+		return
+	}
+
+	mentioned := make(map[uint64]map[string]uuid.UUID)
+	for _, m := range stored {
+		if _, ok := mentioned[m.TweetID]; !ok {
+			mentioned[m.TweetID] = make(map[string]uuid.UUID)
+		}
+		mentioned[m.TweetID][m.UserName] = m.UserID
+	}
+
+	for _, t := range tweets {
+		users, ok := mentioned[t.ID]
+		if !ok {
+			continue
+		}
+		for _, mention := range utils.ExtractMentions(t.Text) {
+			if userID, ok := users[mention.UserName]; ok {
+				mention.TweetID = t.ID
+				mention.UserID = userID
+				t.Mentions = append(t.Mentions, mention)
+			}
+		}
+	}
+}
+
+// Push a new tweet into the author's and followers' home timelines.
+// Accounts with more followers than the fanout threshold are merged on read instead.
+func (u *tweetUC) fanoutTweet(ctx context.Context, tweet *models.Tweet) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.fanoutTweet", nil)
+	defer span.End()
+
+	userIDs := u.getTimelineUserIDs(ctx, tweet.UserID)
+
+	if err := u.tweetRedisRepo.FanoutTimelineCtx(ctx, u.getTimelineKeys(userIDs), u.cfg.Timeline.MaxSize, tweet.ID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.fanoutTweet.FanoutTimelineCtx: %v", err)
+	}
+
+	if err := u.streamUC.Publish(ctx, models.EventTweet, tweet, userIDs...); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.fanoutTweet.Publish: %v", err)
+	}
+}
+
+// Get users whose home timelines tweets of the author are pushed into, the author and
+// the followers unless the author has more followers than the fanout threshold
+func (u *tweetUC) getTimelineUserIDs(ctx context.Context, authorID uuid.UUID) []uuid.UUID {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.getTimelineUserIDs", nil)
+	defer span.End()
+
+	userIDs := []uuid.UUID{authorID}
+
+	followersCount, err := u.followRepo.GetFollowersCount(ctx, authorID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.getTimelineUserIDs.GetFollowersCount: %v", err)
+	} else if followersCount < u.cfg.Timeline.FanoutThreshold {
+		followerIDs, err := u.followRepo.GetFollowerIDs(ctx, authorID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			u.logger.Errorf("tweetUC.getTimelineUserIDs.GetFollowerIDs: %v", err)
+		}
+		userIDs = append(userIDs, followerIDs...)
+	}
+
+	return userIDs
+}
+
+func (u *tweetUC) getTimelineKeys(userIDs []uuid.UUID) []string {
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, u.generateTimelineKey(userID.String()))
+	}
+	return keys
+}
+
+// Rebuild a missing or partial home timeline from postgres
+func (u *tweetUC) rebuildTimeline(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := tracer.NewSpan(ctx, "tweetUC.rebuildTimeline", nil)
+	defer span.End()
+
+	tweetIDs, err := u.tweetRepo.GetHomeTweetIDs(ctx, userID, int(u.cfg.Timeline.MaxSize))
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, err
+	}
+
+	key := u.generateTimelineKey(userID.String())
+	if err = u.tweetRedisRepo.AddTimelineCtx(ctx, key, u.cfg.Timeline.MaxSize, tweetIDs...); err != nil {
+		tracer.AddSpanError(span, err)
+		return 0, err
+	}
+
+	if err = u.tweetRedisRepo.SetTimelineBuiltCtx(ctx, u.generateTimelineBuiltKey(userID.String())); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("tweetUC.rebuildTimeline.SetTimelineBuiltCtx: %v", err)
+	}
+
+	return u.tweetRedisRepo.GetTimelineCountCtx(ctx, key)
+}
+
+// Send notification, failures do not fail the tweet
+func (u *tweetUC) notify(ctx context.Context, n *models.Notification) {
+	if err := u.notificationUC.Notify(ctx, n); err != nil {
+		u.logger.Errorf("tweetUC.notify.Notify: %v", err)
+	}
+}
+
+func (u *tweetUC) generateTimelineKey(user string) string {
+	return fmt.Sprintf("%s: %s %s", basePrefix, "timeline of", user)
+}
+
+func (u *tweetUC) generateTimelineBuiltKey(user string) string {
+	return fmt.Sprintf("%s: %s %s", basePrefix, "timeline built of", user)
+}
+
+// Merge tweet ids newest first, dropping duplicates
+func mergeTweetIDs(a []uint64, b []uint64) []uint64 {
+	seen := make(map[uint64]struct{}, len(a)+len(b))
+	merged := make([]uint64, 0, len(a)+len(b))
+	for _, ids := range [][]uint64{a, b} {
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			merged = append(merged, id)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i] > merged[j] })
+	return merged
+}
@@ -0,0 +1,10 @@
+package upload
+
+import "github.com/labstack/echo/v4"
+
+// Upload HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetUploadByID() echo.HandlerFunc
+	Complete() echo.HandlerFunc
+}
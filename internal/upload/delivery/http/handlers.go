@@ -0,0 +1,135 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/upload"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Upload handlers
+type UploadHandlers struct {
+	cfg      *config.Config
+	uploadUC upload.UseCase
+	logger   logger.Logger
+}
+
+// NewUploadHandlers Upload handlers constructor
+func NewUploadHandlers(cfg *config.Config, uploadUC upload.UseCase, logger logger.Logger) upload.Handlers {
+	return &UploadHandlers{cfg: cfg, uploadUC: uploadUC, logger: logger}
+}
+
+// Create godoc
+// @Summary Start upload
+// @Description Start upload session of an avatar, header or tweet media, returns the presigned url to PUT the file to with the same content type
+// @Tags Upload
+// @Accept json
+// @Param body body models.UploadSession true "purpose and content_type"
+// @Produce json
+// @Success 201 {object} models.UploadSession
+// @Failure 400 {object} httpErrors.RestError
+// @Router /uploads [post]
+func (h *UploadHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UploadHandlers.Create", nil)
+		defer span.End()
+
+		session := &models.UploadSession{}
+		if err := utils.ReadRequest(c, session); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		createdSession, err := h.uploadUC.Create(ctx, session)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdSession)
+	}
+}
+
+// GetUploadByID godoc
+// @Summary Get upload
+// @Description Get upload session of current user
+// @Tags Upload
+// @Accept json
+// @Param id path string true "upload_id"
+// @Produce json
+// @Success 200 {object} models.UploadSession
+// @Failure 404 {object} httpErrors.RestError
+// @Router /uploads/{id} [get]
+func (h *UploadHandlers) GetUploadByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UploadHandlers.GetUploadByID", nil)
+		defer span.End()
+
+		uploadID, err := uuid.Parse(c.Param("upload_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		session, err := h.uploadUC.GetUploadByID(ctx, uploadID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, session)
+	}
+}
+
+// Complete godoc
+// @Summary Complete upload
+// @Description Validate the uploaded file and attach it as avatar, header or media, returns the session with the updated user or the media
+// @Tags Upload
+// @Accept json
+// @Param id path string true "upload_id"
+// @Param body body models.UploadCompletion false "alt text of media"
+// @Produce json
+// @Success 200 {object} models.UploadSession
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /uploads/{id}/complete [post]
+func (h *UploadHandlers) Complete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UploadHandlers.Complete", nil)
+		defer span.End()
+
+		uploadID, err := uuid.Parse(c.Param("upload_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(httpErrors.NewBadRequestError(err)))
+		}
+
+		completion := &models.UploadCompletion{}
+		if err = c.Bind(completion); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		session, err := h.uploadUC.Complete(ctx, uploadID, completion)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, session)
+	}
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/JamesHsu333/go-twitter/internal/middleware"
+	"github.com/JamesHsu333/go-twitter/internal/upload"
+	"github.com/labstack/echo/v4"
+)
+
+// Map upload routes
+func MapUploadRoutes(uploadGroup *echo.Group, h upload.Handlers, mw *middleware.MiddlewareManager) {
+	uploadGroup.Use(mw.AuthSessionMiddleware)
+	uploadGroup.POST("", h.Create(), mw.CSRF)
+	uploadGroup.GET("/:upload_id", h.GetUploadByID())
+	uploadGroup.POST("/:upload_id/complete", h.Complete(), mw.CSRF)
+}
@@ -0,0 +1,17 @@
+package upload
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
+)
+
+// Upload repository interface
+type Repository interface {
+	Create(ctx context.Context, session *models.UploadSession) (*models.UploadSession, error)
+	GetUploadByID(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*models.UploadSession, error)
+	ClaimUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*models.UploadSession, error)
+	MarkCompleted(ctx context.Context, uploadID uuid.UUID, mediaID *uint64) error
+	MarkFailed(ctx context.Context, uploadID uuid.UUID) error
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/upload"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Upload repository
+type uploadRepo struct {
+	db *sqlx.DB
+}
+
+func NewUploadRepository(db *sqlx.DB) upload.Repository {
+	return &uploadRepo{db: db}
+}
+
+func (r *uploadRepo) Create(ctx context.Context, session *models.UploadSession) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadRepo.Create", nil)
+	defer span.End()
+
+	s := &models.UploadSession{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createUploadQuery,
+		&session.ID,
+		&session.UserID,
+		&session.Purpose,
+		&session.ObjectKey,
+		&session.ContentType,
+		&session.ExpiresAt,
+	).StructScan(s); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "uploadRepo.Create.StructScan")
+	}
+	return s, nil
+}
+
+func (r *uploadRepo) GetUploadByID(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadRepo.GetUploadByID", nil)
+	defer span.End()
+
+	s := &models.UploadSession{}
+	if err := r.db.QueryRowxContext(ctx, getUploadQuery, uploadID, userID).StructScan(s); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "uploadRepo.GetUploadByID.StructScan")
+	}
+	return s, nil
+}
+
+// Mark pending upload as completing, so that it is completed once
+func (r *uploadRepo) ClaimUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadRepo.ClaimUpload", nil)
+	defer span.End()
+
+	s := &models.UploadSession{}
+	if err := r.db.GetContext(ctx, s, claimUploadQuery, uploadID, userID); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, errors.Wrap(err, "uploadRepo.ClaimUpload.GetContext")
+	}
+	return s, nil
+}
+
+func (r *uploadRepo) MarkCompleted(ctx context.Context, uploadID uuid.UUID, mediaID *uint64) error {
+	ctx, span := tracer.NewSpan(ctx, "uploadRepo.MarkCompleted", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, markCompletedQuery, uploadID, mediaID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "uploadRepo.MarkCompleted.ExecContext")
+	}
+
+	return nil
+}
+
+func (r *uploadRepo) MarkFailed(ctx context.Context, uploadID uuid.UUID) error {
+	ctx, span := tracer.NewSpan(ctx, "uploadRepo.MarkFailed", nil)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, markFailedQuery, uploadID); err != nil {
+		tracer.AddSpanError(span, err)
+		return errors.WithMessage(err, "uploadRepo.MarkFailed.ExecContext")
+	}
+
+	return nil
+}
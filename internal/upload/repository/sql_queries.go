@@ -0,0 +1,18 @@
+package repository
+
+const (
+	createUploadQuery = `INSERT INTO upload_sessions (id, user_id, purpose, object_key, content_type, expires_at, created_at, updated_at)
+						 VALUES ($1, $2, $3, $4, $5, $6, now(), now())
+						 RETURNING *`
+
+	getUploadQuery = `SELECT * FROM upload_sessions WHERE id = $1 AND user_id = $2`
+
+	claimUploadQuery = `UPDATE upload_sessions
+						SET status = 'completing', updated_at = now()
+						WHERE id = $1 AND user_id = $2 AND status = 'pending' AND expires_at > now()
+						RETURNING *`
+
+	markCompletedQuery = `UPDATE upload_sessions SET status = 'completed', media_id = $2, updated_at = now() WHERE id = $1`
+
+	markFailedQuery = `UPDATE upload_sessions SET status = 'failed', updated_at = now() WHERE id = $1`
+)
@@ -0,0 +1,15 @@
+package upload
+
+import (
+	"context"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/google/uuid"
+)
+
+// Upload usecase interface
+type UseCase interface {
+	Create(ctx context.Context, session *models.UploadSession) (*models.UploadSession, error)
+	GetUploadByID(ctx context.Context, uploadID uuid.UUID) (*models.UploadSession, error)
+	Complete(ctx context.Context, uploadID uuid.UUID, completion *models.UploadCompletion) (*models.UploadSession, error)
+}
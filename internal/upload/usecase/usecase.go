@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/file"
+	"github.com/JamesHsu333/go-twitter/internal/media"
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/internal/upload"
+	"github.com/JamesHsu333/go-twitter/internal/user"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/JamesHsu333/go-twitter/pkg/logger"
+	"github.com/JamesHsu333/go-twitter/pkg/tracer"
+	"github.com/JamesHsu333/go-twitter/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Objects of upload sessions are stored under this prefix until completed,
+// objects of sessions never completed are removed by the orphaned file collector
+const uploadKeyPrefix = "uploads/"
+
+// Upload Usecase
+type uploadUC struct {
+	cfg        *config.Config
+	uploadRepo upload.Repository
+	fileRepo   file.FileRepository
+	fileUC     file.UseCase
+	mediaUC    media.UseCase
+	userUC     user.UseCase
+	logger     logger.Logger
+}
+
+// New Usecase
+func NewUploadUseCase(cfg *config.Config, uploadRepo upload.Repository, fileRepo file.FileRepository, fileUC file.UseCase, mediaUC media.UseCase, userUC user.UseCase, logger logger.Logger) upload.UseCase {
+	return &uploadUC{
+		cfg:        cfg,
+		uploadRepo: uploadRepo,
+		fileRepo:   fileRepo,
+		fileUC:     fileUC,
+		mediaUC:    mediaUC,
+		userUC:     userUC,
+		logger:     logger,
+	}
+}
+
+// Start upload session, returning the presigned url the client uploads the object to
+func (u *uploadUC) Create(ctx context.Context, session *models.UploadSession) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadUC.Create", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "uploadUC.Create.GetUserFromCtx"))
+	}
+
+	if err = utils.ValidateStruct(ctx, session); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.Create.ValidateStruct"))
+	}
+
+	expires := time.Duration(u.cfg.Upload.ExpireSeconds) * time.Second
+	session.ID = uuid.New()
+	session.UserID = self.UserID
+	session.ObjectKey = uploadKeyPrefix + session.ID.String()
+	session.ExpiresAt = time.Now().Add(expires)
+
+	uploadURL, err := u.fileRepo.PresignPutObject(ctx, session.ObjectKey, session.ContentType, expires)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.Create.PresignPutObject"))
+	}
+
+	createdSession, err := u.uploadRepo.Create(ctx, session)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+	createdSession.UploadURL = uploadURL
+
+	return createdSession, nil
+}
+
+func (u *uploadUC) GetUploadByID(ctx context.Context, uploadID uuid.UUID) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadUC.GetUploadByID", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "uploadUC.GetUploadByID.GetUserFromCtx"))
+	}
+
+	return u.uploadRepo.GetUploadByID(ctx, self.UserID, uploadID)
+}
+
+// Validate uploaded object and attach it for the purpose of the session.
+// The uploaded object is removed either way, attachments are stored as processed images.
+func (u *uploadUC) Complete(ctx context.Context, uploadID uuid.UUID, completion *models.UploadCompletion) (*models.UploadSession, error) {
+	ctx, span := tracer.NewSpan(ctx, "uploadUC.Complete", nil)
+	defer span.End()
+
+	self, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewUnauthorizedError(errors.WithMessage(err, "uploadUC.Complete.GetUserFromCtx"))
+	}
+
+	if err = utils.ValidateStruct(ctx, completion); err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.Complete.ValidateStruct"))
+	}
+
+	// Only pending sessions not expired yet can be completed
+	session, err := u.uploadRepo.ClaimUpload(ctx, self.UserID, uploadID)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return nil, err
+	}
+
+	objectURL := u.fileRepo.ObjectURL(session.ObjectKey)
+	defer func() {
+		if err := u.fileRepo.RemoveObject(ctx, objectURL); err != nil {
+			u.logger.Errorf("uploadUC.Complete.RemoveObject: %v", err)
+		}
+	}()
+
+	if err = u.attach(ctx, self, session, objectURL, completion); err != nil {
+		tracer.AddSpanError(span, err)
+		if markErr := u.uploadRepo.MarkFailed(ctx, session.ID); markErr != nil {
+			u.logger.Errorf("uploadUC.Complete.MarkFailed: %v", markErr)
+		}
+		return nil, err
+	}
+
+	if err = u.uploadRepo.MarkCompleted(ctx, session.ID, session.MediaID); err != nil {
+		tracer.AddSpanError(span, err)
+		u.logger.Errorf("uploadUC.Complete.MarkCompleted: %v", err)
+	}
+	session.Status = models.UploadCompleted
+
+	return session, nil
+}
+
+func (u *uploadUC) attach(ctx context.Context, self *models.User, session *models.UploadSession, objectURL string, completion *models.UploadCompletion) error {
+	ctx, span := tracer.NewSpan(ctx, "uploadUC.attach", nil)
+	defer span.End()
+
+	content, err := u.readObject(ctx, objectURL)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	if contentType := http.DetectContentType(content); contentType != session.ContentType {
+		err = errors.Errorf("uploaded %s does not match content type %s", contentType, session.ContentType)
+		tracer.AddSpanError(span, err)
+		return httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.attach.DetectContentType"))
+	}
+
+	if session.Purpose == models.UploadMedia {
+		session.Media, err = u.mediaUC.Upload(ctx, content, completion.AltText)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			return err
+		}
+		session.MediaID = &session.Media.ID
+		return nil
+	}
+
+	image, err := u.fileUC.PutImage(ctx, content)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		return err
+	}
+
+	previous := self.Avatar
+	if session.Purpose == models.UploadHeader {
+		previous = self.Header
+		self.Header = &image.URL
+	} else {
+		self.Avatar = &image.URL
+	}
+
+	session.User, err = u.userUC.Update(ctx, self)
+	if err != nil {
+		tracer.AddSpanError(span, err)
+		if rmErr := u.fileUC.RemoveImage(ctx, self.UserID, image.URL); rmErr != nil {
+			u.logger.Errorf("uploadUC.attach.RemoveImage: %v", rmErr)
+		}
+		return err
+	}
+
+	if previous != nil {
+		if err = u.fileUC.RemoveImage(ctx, self.UserID, *previous); err != nil {
+			u.logger.Errorf("uploadUC.attach.RemoveImage: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Read uploaded object, rejecting objects over the size limit without reading them whole
+func (u *uploadUC) readObject(ctx context.Context, objectURL string) ([]byte, error) {
+	body, err := u.fileRepo.GetObject(ctx, objectURL)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.readObject.GetObject"))
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, u.cfg.Upload.MaxSizeBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadUC.readObject.ReadAll")
+	}
+	if int64(len(content)) > u.cfg.Upload.MaxSizeBytes {
+		err = errors.Errorf("upload is larger than %d bytes", u.cfg.Upload.MaxSizeBytes)
+		return nil, httpErrors.NewBadRequestError(errors.WithMessage(err, "uploadUC.readObject"))
+	}
+
+	return content, nil
+}
@@ -16,14 +16,31 @@ type Handlers interface {
 	GetFollowers() echo.HandlerFunc
 	GetFollowing() echo.HandlerFunc
 	DeleteFollowing() echo.HandlerFunc
+	GetFollowRequests() echo.HandlerFunc
+	ApproveFollowRequest() echo.HandlerFunc
+	DenyFollowRequest() echo.HandlerFunc
+	Block() echo.HandlerFunc
+	GetBlocking() echo.HandlerFunc
+	Unblock() echo.HandlerFunc
+	Mute() echo.HandlerFunc
+	GetMuting() echo.HandlerFunc
+	Unmute() echo.HandlerFunc
 	FindByName() echo.HandlerFunc
 	GetUsers() echo.HandlerFunc
 	GetMe() echo.HandlerFunc
 	UploadAvatar() echo.HandlerFunc
 	UploadHeader() echo.HandlerFunc
+	GetStorageUsage() echo.HandlerFunc
 	GetCSRFToken() echo.HandlerFunc
 	Like() echo.HandlerFunc
 	GetLikedTweets() echo.HandlerFunc
 	DeleteLiked() echo.HandlerFunc
+	Bookmark() echo.HandlerFunc
+	GetBookmarkedTweets() echo.HandlerFunc
+	DeleteBookmark() echo.HandlerFunc
+	CreateBookmarkFolder() echo.HandlerFunc
+	GetBookmarkFolders() echo.HandlerFunc
+	DeleteBookmarkFolder() echo.HandlerFunc
 	GetTweetsByUserID() echo.HandlerFunc
+	GetMentionTweets() echo.HandlerFunc
 }
@@ -7,6 +7,9 @@ import (
 	"strconv"
 
 	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/JamesHsu333/go-twitter/internal/auth"
+	"github.com/JamesHsu333/go-twitter/internal/block"
+	"github.com/JamesHsu333/go-twitter/internal/bookmark"
 	"github.com/JamesHsu333/go-twitter/internal/file"
 	"github.com/JamesHsu333/go-twitter/internal/follow"
 	"github.com/JamesHsu333/go-twitter/internal/like"
@@ -26,34 +29,40 @@ import (
 
 // User handlers
 type UserHandlers struct {
-	cfg      *config.Config
-	userUC   user.UseCase
-	sessUC   session.UCSession
-	fileUC   file.UseCase
-	followUC follow.UseCase
-	likeUC   like.UseCase
-	tweetUC  tweet.UseCase
-	logger   logger.Logger
+	cfg        *config.Config
+	userUC     user.UseCase
+	sessUC     session.UCSession
+	authUC     auth.UseCase
+	fileUC     file.UseCase
+	followUC   follow.UseCase
+	blockUC    block.UseCase
+	likeUC     like.UseCase
+	bookmarkUC bookmark.UseCase
+	tweetUC    tweet.UseCase
+	logger     logger.Logger
 }
 
 // NewUserHandlers User handlers constructor
-func NewUserHandlers(cfg *config.Config, userUC user.UseCase, sessUC session.UCSession, fileUC file.FileRepository,
-	followUC follow.UseCase, likeUC like.UseCase, tweetUC tweet.UseCase, log logger.Logger) user.Handlers {
+func NewUserHandlers(cfg *config.Config, userUC user.UseCase, sessUC session.UCSession, authUC auth.UseCase, fileUC file.UseCase,
+	followUC follow.UseCase, blockUC block.UseCase, likeUC like.UseCase, bookmarkUC bookmark.UseCase, tweetUC tweet.UseCase, log logger.Logger) user.Handlers {
 	return &UserHandlers{
-		cfg:      cfg,
-		userUC:   userUC,
-		sessUC:   sessUC,
-		fileUC:   fileUC,
-		followUC: followUC,
-		likeUC:   likeUC,
-		tweetUC:  tweetUC,
-		logger:   log,
+		cfg:        cfg,
+		userUC:     userUC,
+		sessUC:     sessUC,
+		authUC:     authUC,
+		fileUC:     fileUC,
+		followUC:   followUC,
+		blockUC:    blockUC,
+		likeUC:     likeUC,
+		bookmarkUC: bookmarkUC,
+		tweetUC:    tweetUC,
+		logger:     log,
 	}
 }
 
 // Register godoc
 // @Summary Register new user
-// @Description register new user, returns user and token
+// @Description register new user, returns user and sets session, tokens are returned as well in token auth mode
 // @Tags User
 // @Accept json
 // @Produce json
@@ -89,13 +98,22 @@ func (h *UserHandlers) Register() echo.HandlerFunc {
 
 		c.SetCookie(utils.CreateSessionCookie(h.cfg, sess))
 
+		if h.cfg.Auth.Mode == auth.TokenMode {
+			createdUser.Tokens, err = h.authUC.IssueTokens(ctx, createdUser.User)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
 		return c.JSON(http.StatusCreated, createdUser)
 	}
 }
 
 // Login godoc
 // @Summary Login new user
-// @Description login user, returns user and set session
+// @Description login user, returns user and set session, tokens are returned as well in token auth mode
 // @Tags User
 // @Accept json
 // @Produce json
@@ -138,6 +156,15 @@ func (h *UserHandlers) Login() echo.HandlerFunc {
 
 		c.SetCookie(utils.CreateSessionCookie(h.cfg, sess))
 
+		if h.cfg.Auth.Mode == auth.TokenMode {
+			userWithToken.Tokens, err = h.authUC.IssueTokens(ctx, userWithToken.User)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+		}
+
 		return c.JSON(http.StatusOK, userWithToken)
 	}
 }
@@ -556,21 +583,13 @@ func (h *UserHandlers) UploadAvatar() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		contentType, err := utils.CheckImageFileContentType(binaryImage.Bytes())
-		if err != nil {
+		if _, err = utils.CheckImageFileContentType(binaryImage.Bytes()); err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		reader := bytes.NewReader(binaryImage.Bytes())
-
-		filepath, err := h.fileUC.PutObject(ctx, models.UploadInput{
-			File:        reader,
-			Name:        image.Filename,
-			Size:        image.Size,
-			ContentType: contentType,
-		})
+		uploadedImage, err := h.fileUC.PutImage(ctx, binaryImage.Bytes())
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
@@ -578,14 +597,14 @@ func (h *UserHandlers) UploadAvatar() echo.HandlerFunc {
 		}
 
 		if user.Avatar != nil {
-			if err = h.fileUC.RemoveObject(ctx, *user.Avatar); err != nil {
+			if err = h.fileUC.RemoveImage(ctx, user.UserID, *user.Avatar); err != nil {
 				tracer.AddSpanError(span, err)
 				utils.LogResponseError(c, h.logger, err)
 				return c.JSON(httpErrors.ErrorResponse(err))
 			}
 		}
 
-		user.Avatar = filepath
+		user.Avatar = &uploadedImage.URL
 
 		updatedUser, err := h.userUC.Update(ctx, user)
 		if err != nil {
@@ -643,21 +662,13 @@ func (h *UserHandlers) UploadHeader() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		contentType, err := utils.CheckImageFileContentType(binaryImage.Bytes())
-		if err != nil {
+		if _, err = utils.CheckImageFileContentType(binaryImage.Bytes()); err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		reader := bytes.NewReader(binaryImage.Bytes())
-
-		filepath, err := h.fileUC.PutObject(ctx, models.UploadInput{
-			File:        reader,
-			Name:        image.Filename,
-			Size:        image.Size,
-			ContentType: contentType,
-		})
+		uploadedImage, err := h.fileUC.PutImage(ctx, binaryImage.Bytes())
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
@@ -665,14 +676,14 @@ func (h *UserHandlers) UploadHeader() echo.HandlerFunc {
 		}
 
 		if user.Header != nil {
-			if err = h.fileUC.RemoveObject(ctx, *user.Header); err != nil {
+			if err = h.fileUC.RemoveImage(ctx, user.UserID, *user.Header); err != nil {
 				tracer.AddSpanError(span, err)
 				utils.LogResponseError(c, h.logger, err)
 				return c.JSON(httpErrors.ErrorResponse(err))
 			}
 		}
 
-		user.Header = filepath
+		user.Header = &uploadedImage.URL
 
 		updatedUser, err := h.userUC.Update(ctx, user)
 		if err != nil {
@@ -685,6 +696,39 @@ func (h *UserHandlers) UploadHeader() echo.HandlerFunc {
 	}
 }
 
+// GetStorageUsage godoc
+// @Summary Get storage usage
+// @Description Get bytes of images stored by current user and the quota of their role
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 200 {object} models.StorageUsage
+// @Failure 403 {object} httpErrors.RestError
+// @Router /users/{id}/storage [get]
+func (h *UserHandlers) GetStorageUsage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetStorageUsage", nil)
+		defer span.End()
+
+		uID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usage, err := h.fileUC.GetStorageUsage(ctx, uID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usage)
+	}
+}
+
 func (h *UserHandlers) GetTweetsByUserID() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetTweetsByUserID", nil)
@@ -715,6 +759,49 @@ func (h *UserHandlers) GetTweetsByUserID() echo.HandlerFunc {
 	}
 }
 
+// GetMentionTweets godoc
+// @Summary Get tweets mentioning user
+// @Description Get the list of tweets that mention a user, newest first
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/mentions [get]
+func (h *UserHandlers) GetMentionTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetMentionTweets", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweets, err := h.tweetUC.GetMentionTweets(ctx, userID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweets)
+	}
+}
+
 // Follow godoc
 // @Summary Follow other user
 // @Description Follow other user
@@ -746,12 +833,16 @@ func (h *UserHandlers) Follow() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if err = h.followUC.Follow(ctx, followerID, following.UserID); err != nil {
+		pending, err := h.followUC.Follow(ctx, followerID, following.UserID)
+		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
+		if pending {
+			return c.NoContent(http.StatusAccepted)
+		}
 		return c.NoContent(http.StatusCreated)
 	}
 }
@@ -830,6 +921,129 @@ func (h *UserHandlers) GetFollowing() echo.HandlerFunc {
 	}
 }
 
+// GetFollowRequests godoc
+// @Summary Get follow requests
+// @Description Get the list of users waiting for approval to follow the private account of current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.UsersList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/follow_requests [get]
+func (h *UserHandlers) GetFollowRequests() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetFollowRequests", nil)
+		defer span.End()
+
+		uID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usersList, err := h.followUC.GetFollowRequests(ctx, uID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usersList)
+	}
+}
+
+// ApproveFollowRequest godoc
+// @Summary Approve follow request
+// @Description Approve follow request of requester, who becomes a follower
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param requester_id path string true "requester_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/follow_requests/{requester_id} [post]
+func (h *UserHandlers) ApproveFollowRequest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.ApproveFollowRequest", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		requesterID, err := uuid.Parse(c.Param("requester_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.followUC.ApproveFollowRequest(ctx, userID, requesterID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// DenyFollowRequest godoc
+// @Summary Deny follow request
+// @Description Deny follow request of requester
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param requester_id path string true "requester_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/follow_requests/{requester_id} [delete]
+func (h *UserHandlers) DenyFollowRequest() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DenyFollowRequest", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		requesterID, err := uuid.Parse(c.Param("requester_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.followUC.DenyFollowRequest(ctx, userID, requesterID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
 func (h *UserHandlers) DeleteFollowing() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DeleteFollowing", nil)
@@ -859,12 +1073,22 @@ func (h *UserHandlers) DeleteFollowing() echo.HandlerFunc {
 	}
 }
 
-func (h *UserHandlers) Like() echo.HandlerFunc {
-	type Like struct {
-		TweetID uint64 `json:"tweet_id" db:"tweet_id" validate:"omitempty"`
+// Block godoc
+// @Summary Block other user
+// @Description Block other user, follows in both directions are removed and neither user sees the tweets of the other
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /users/{id}/blocking [post]
+func (h *UserHandlers) Block() echo.HandlerFunc {
+	type Target struct {
+		UserID uuid.UUID `json:"user_id" validate:"required"`
 	}
 	return func(c echo.Context) error {
-		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Like", nil)
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Block", nil)
 		defer span.End()
 
 		userID, err := uuid.Parse(c.Param("user_id"))
@@ -874,14 +1098,14 @@ func (h *UserHandlers) Like() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		like := &Like{}
-		if err := utils.ReadRequest(c, like); err != nil {
+		target := &Target{}
+		if err := utils.ReadRequest(c, target); err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if err = h.likeUC.Like(ctx, userID, like.TweetID); err != nil {
+		if err = h.blockUC.Block(ctx, userID, target.UserID); err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
@@ -891,12 +1115,25 @@ func (h *UserHandlers) Like() echo.HandlerFunc {
 	}
 }
 
-func (h *UserHandlers) GetLikedTweets() echo.HandlerFunc {
+// GetBlocking godoc
+// @Summary Get blocked users
+// @Description Get the list of users blocked by current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.UsersList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/blocking [get]
+func (h *UserHandlers) GetBlocking() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetLikedTweets", nil)
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetBlocking", nil)
 		defer span.End()
 
-		userID, err := uuid.Parse(c.Param("user_id"))
+		uID, err := uuid.Parse(c.Param("user_id"))
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
@@ -910,20 +1147,31 @@ func (h *UserHandlers) GetLikedTweets() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		tweets, err := h.likeUC.GetLikedTweets(ctx, userID, paginationQuery)
+		usersList, err := h.blockUC.GetBlocked(ctx, uID, paginationQuery)
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		return c.JSON(http.StatusOK, tweets)
+		return c.JSON(http.StatusOK, usersList)
 	}
 }
 
-func (h *UserHandlers) DeleteLiked() echo.HandlerFunc {
+// Unblock godoc
+// @Summary Unblock user
+// @Description Unblock user blocked by current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param blocked_id path string true "blocked_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/blocking/{blocked_id} [delete]
+func (h *UserHandlers) Unblock() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DeleteLiked", nil)
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Unblock", nil)
 		defer span.End()
 
 		userID, err := uuid.Parse(c.Param("user_id"))
@@ -933,14 +1181,489 @@ func (h *UserHandlers) DeleteLiked() echo.HandlerFunc {
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		targetID, err := uuid.Parse(c.Param("blocked_id"))
 		if err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
 		}
 
-		if err = h.likeUC.Delete(ctx, userID, tweetID); err != nil {
+		if err = h.blockUC.Unblock(ctx, userID, targetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Mute godoc
+// @Summary Mute other user
+// @Description Mute other user, tweets of muted user are left out of the feeds of current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 400 {object} httpErrors.RestError
+// @Router /users/{id}/muting [post]
+func (h *UserHandlers) Mute() echo.HandlerFunc {
+	type Target struct {
+		UserID uuid.UUID `json:"user_id" validate:"required"`
+	}
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Mute", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		target := &Target{}
+		if err := utils.ReadRequest(c, target); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.blockUC.Mute(ctx, userID, target.UserID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// GetMuting godoc
+// @Summary Get muted users
+// @Description Get the list of users muted by current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.UsersList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/muting [get]
+func (h *UserHandlers) GetMuting() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetMuting", nil)
+		defer span.End()
+
+		uID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		usersList, err := h.blockUC.GetMuted(ctx, uID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, usersList)
+	}
+}
+
+// Unmute godoc
+// @Summary Unmute user
+// @Description Unmute user muted by current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param muted_id path string true "muted_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/muting/{muted_id} [delete]
+func (h *UserHandlers) Unmute() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Unmute", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		targetID, err := uuid.Parse(c.Param("muted_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.blockUC.Unmute(ctx, userID, targetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func (h *UserHandlers) Like() echo.HandlerFunc {
+	type Like struct {
+		TweetID uint64 `json:"tweet_id" db:"tweet_id" validate:"omitempty"`
+	}
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Like", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		like := &Like{}
+		if err := utils.ReadRequest(c, like); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.likeUC.Like(ctx, userID, like.TweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+func (h *UserHandlers) GetLikedTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetLikedTweets", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		self, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweets, err := h.likeUC.GetLikedTweets(ctx, self.UserID, userID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweets)
+	}
+}
+
+func (h *UserHandlers) DeleteLiked() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DeleteLiked", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.likeUC.Delete(ctx, userID, tweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Bookmark godoc
+// @Summary Bookmark tweet
+// @Description Bookmark tweet privately, optionally into a folder of current user. Bookmarking a tweet again moves it to the given folder
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 201 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/bookmarks [post]
+func (h *UserHandlers) Bookmark() echo.HandlerFunc {
+	type Bookmark struct {
+		TweetID  uint64  `json:"tweet_id" validate:"required"`
+		FolderID *uint64 `json:"folder_id" validate:"omitempty"`
+	}
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.Bookmark", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		bookmark := &Bookmark{}
+		if err := utils.ReadRequest(c, bookmark); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.bookmarkUC.Bookmark(ctx, userID, bookmark.TweetID, bookmark.FolderID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusCreated)
+	}
+}
+
+// GetBookmarkedTweets godoc
+// @Summary Get bookmarked tweets
+// @Description Get tweets bookmarked by current user, all of them or only those in a folder
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param folder_id query int false "bookmark folder id"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param cursor query string false "cursor from next_cursor, empty for the first page of cursor mode"
+// @Produce json
+// @Success 200 {object} models.TweetsList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/bookmarks [get]
+func (h *UserHandlers) GetBookmarkedTweets() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetBookmarkedTweets", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		var folderID *uint64
+		if folder := c.QueryParam("folder_id"); folder != "" {
+			id, err := strconv.ParseUint(folder, 10, 64)
+			if err != nil {
+				tracer.AddSpanError(span, err)
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(httpErrors.ErrorResponse(err))
+			}
+			folderID = &id
+		}
+
+		paginationQuery, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweets, err := h.bookmarkUC.GetBookmarkedTweets(ctx, userID, folderID, paginationQuery)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, tweets)
+	}
+}
+
+// DeleteBookmark godoc
+// @Summary Delete bookmark
+// @Description Remove tweet from bookmarks of current user
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param tweet_id path int true "tweet_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/bookmarks/{tweet_id} [delete]
+func (h *UserHandlers) DeleteBookmark() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DeleteBookmark", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		tweetID, err := strconv.ParseUint(c.Param("tweet_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.bookmarkUC.Delete(ctx, userID, tweetID); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// CreateBookmarkFolder godoc
+// @Summary Create bookmark folder
+// @Description Create bookmark folder of current user, returns folder
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 201 {object} models.BookmarkFolder
+// @Failure 400 {object} httpErrors.RestError
+// @Router /users/{id}/bookmark_folders [post]
+func (h *UserHandlers) CreateBookmarkFolder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.CreateBookmarkFolder", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		folder := &models.BookmarkFolder{}
+		if err := utils.ReadRequest(c, folder); err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+		folder.UserID = userID
+
+		createdFolder, err := h.bookmarkUC.CreateFolder(ctx, folder)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, createdFolder)
+	}
+}
+
+// GetBookmarkFolders godoc
+// @Summary Get bookmark folders
+// @Description Get bookmark folders of current user with their bookmark counts
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Produce json
+// @Success 200 {object} models.BookmarkFoldersList
+// @Failure 500 {object} httpErrors.RestError
+// @Router /users/{id}/bookmark_folders [get]
+func (h *UserHandlers) GetBookmarkFolders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.GetBookmarkFolders", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		folders, err := h.bookmarkUC.GetFolders(ctx, userID)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, folders)
+	}
+}
+
+// DeleteBookmarkFolder godoc
+// @Summary Delete bookmark folder
+// @Description Delete bookmark folder of current user, its bookmarks are kept without a folder
+// @Tags User
+// @Accept json
+// @Param id path string true "user_id"
+// @Param folder_id path int true "folder_id"
+// @Produce json
+// @Success 204 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /users/{id}/bookmark_folders/{folder_id} [delete]
+func (h *UserHandlers) DeleteBookmarkFolder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracer.NewSpan(utils.GetRequestCtx(c), "UserHandlers.DeleteBookmarkFolder", nil)
+		defer span.End()
+
+		userID, err := uuid.Parse(c.Param("user_id"))
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		folderID, err := strconv.ParseUint(c.Param("folder_id"), 10, 64)
+		if err != nil {
+			tracer.AddSpanError(span, err)
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(httpErrors.ErrorResponse(err))
+		}
+
+		if err = h.bookmarkUC.DeleteFolder(ctx, userID, folderID); err != nil {
 			tracer.AddSpanError(span, err)
 			utils.LogResponseError(c, h.logger, err)
 			return c.JSON(httpErrors.ErrorResponse(err))
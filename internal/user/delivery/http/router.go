@@ -19,16 +19,33 @@ func MapUserRoutes(userGroup *echo.Group, h user.Handlers, mw *middleware.Middle
 	userGroup.GET("/username/:user_name", h.GetUserByUserName())
 	userGroup.GET("/:user_id/followers", h.GetFollowers())
 	userGroup.GET("/:user_id/following", h.GetFollowing())
+	userGroup.GET("/:user_id/follow_requests", h.GetFollowRequests(), mw.OwnerMiddleware())
+	userGroup.GET("/:user_id/blocking", h.GetBlocking(), mw.OwnerMiddleware())
+	userGroup.GET("/:user_id/muting", h.GetMuting(), mw.OwnerMiddleware())
+	userGroup.GET("/:user_id/bookmarks", h.GetBookmarkedTweets(), mw.OwnerMiddleware())
+	userGroup.GET("/:user_id/bookmark_folders", h.GetBookmarkFolders(), mw.OwnerMiddleware())
+	userGroup.GET("/:user_id/storage", h.GetStorageUsage(), mw.OwnerMiddleware())
 	userGroup.GET("/token", h.GetCSRFToken())
 	userGroup.GET("/:user_id/tweets", h.GetTweetsByUserID())
+	userGroup.GET("/:user_id/mentions", h.GetMentionTweets())
 	userGroup.GET("/:user_id/liked_tweets", h.GetLikedTweets())
 	userGroup.POST("/:user_id/avatar", h.UploadAvatar(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.POST("/:user_id/header", h.UploadHeader(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.POST("/:user_id/following", h.Follow(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.POST("/:user_id/liked", h.Like(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.POST("/:user_id/follow_requests/:requester_id", h.ApproveFollowRequest(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.POST("/:user_id/blocking", h.Block(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.POST("/:user_id/muting", h.Mute(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.POST("/:user_id/bookmarks", h.Bookmark(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.POST("/:user_id/bookmark_folders", h.CreateBookmarkFolder(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.PATCH("/:user_id", h.Update(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.PATCH("/:user_id/role", h.UpdateRole(), mw.RoleBasedAuthMiddleware([]string{"admin"}), mw.CSRF)
 	userGroup.DELETE("/:user_id", h.Delete(), mw.CSRF, mw.RoleBasedAuthMiddleware([]string{"admin"}))
 	userGroup.DELETE("/:user_id/following/:following_id", h.DeleteFollowing(), mw.OwnerMiddleware(), mw.CSRF)
 	userGroup.DELETE("/:user_id/liked/:tweet_id", h.DeleteLiked(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.DELETE("/:user_id/follow_requests/:requester_id", h.DenyFollowRequest(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.DELETE("/:user_id/bookmarks/:tweet_id", h.DeleteBookmark(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.DELETE("/:user_id/bookmark_folders/:folder_id", h.DeleteBookmarkFolder(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.DELETE("/:user_id/blocking/:blocked_id", h.Unblock(), mw.OwnerMiddleware(), mw.CSRF)
+	userGroup.DELETE("/:user_id/muting/:muted_id", h.Unmute(), mw.OwnerMiddleware(), mw.CSRF)
 }
@@ -47,7 +47,7 @@ func (r *userRepo) Update(ctx context.Context, user *models.User) (*models.User,
 	u := &models.User{}
 	if err := r.db.GetContext(ctx, u, updateUserQuery, &user.UserName, &user.Name, &user.Email,
 		&user.About, &user.Avatar, &user.Header, &user.PhoneNumber, &user.Country, &user.Gender,
-		utils.ParseTimeFormat(user.Birthday), &user.UserID,
+		utils.ParseTimeFormat(user.Birthday), &user.UserID, user.AllowDMs, user.IsPrivate,
 	); err != nil {
 		tracer.AddSpanError(span, err)
 		return nil, errors.Wrap(err, "userRepo.Update.GetContext")
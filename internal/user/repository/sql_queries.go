@@ -17,6 +17,8 @@ const (
 						    country = COALESCE(NULLIF($8, ''), country),
 						    gender = COALESCE(NULLIF($9, ''), gender),
 						    birthday = COALESCE(NULLIF($10, '')::date, birthday),
+						    allow_dms = COALESCE($12, allow_dms),
+						    is_private = COALESCE($13, is_private),
 						    updated_at = now()
 						WHERE user_id = $11
 						RETURNING *
@@ -26,7 +28,7 @@ const (
 
 	getUserQuery = `WITH __u AS 
 						(SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
-						u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+						u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date, u.is_private,
 						COUNT(distinct f1.following_id) AS following, COUNT(distinct f2.follower_id) AS followers
 						FROM users u
 						LEFT JOIN follows f1 ON f1.follower_id = u.user_id
@@ -35,15 +37,16 @@ const (
 						GROUP BY u.user_id
 						)
 					SELECT __u.user_id, __u.user_name, __u.name, __u.email, __u.role, __u.about, __u.avatar, __u.header,
-					__u.phone_number, __u.country, __u.gender, __u.birthday, __u.created_at, __u.updated_at, __u.login_date,
+					__u.phone_number, __u.country, __u.gender, __u.birthday, __u.created_at, __u.updated_at, __u.login_date, __u.is_private,
 					__u.following, __u.followers,
-					EXISTS (SELECT 1 FROM follows f where __u.user_id = f.following_id and f.follower_id = $1) AS is_following
+					EXISTS (SELECT 1 FROM follows f where __u.user_id = f.following_id and f.follower_id = $1) AS is_following,
+					EXISTS (SELECT 1 FROM follow_requests fr where __u.user_id = fr.target_id and fr.requester_id = $1) AS is_requested
 					FROM __u
 					`
 
 	getUserByUserNameQuery = `WITH __u AS 
 								(SELECT u.user_id, u.user_name, u.name, u.email, u.role, u.about, u.avatar, u.header,
-								u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date,
+								u.phone_number, u.country, u.gender, u.birthday, u.created_at, u.updated_at, u.login_date, u.is_private,
 								COUNT(distinct f1.following_id) AS following, COUNT(distinct f2.follower_id) AS followers
 								FROM users u
 								LEFT JOIN follows f1 ON f1.follower_id = u.user_id
@@ -52,9 +55,10 @@ const (
 								GROUP BY u.user_id
 								)
 							 SELECT __u.user_id, __u.user_name, __u.name, __u.email, __u.role, __u.about, __u.avatar, __u.header,
-							 __u.phone_number, __u.country, __u.gender, __u.birthday, __u.created_at, __u.updated_at, __u.login_date,
+							 __u.phone_number, __u.country, __u.gender, __u.birthday, __u.created_at, __u.updated_at, __u.login_date, __u.is_private,
 							 __u.following, __u.followers,
-							 EXISTS (SELECT 1 FROM follows f where __u.user_id = f.following_id and f.follower_id = $1) AS is_following
+							 EXISTS (SELECT 1 FROM follows f where __u.user_id = f.following_id and f.follower_id = $1) AS is_following,
+							 EXISTS (SELECT 1 FROM follow_requests fr where __u.user_id = fr.target_id and fr.requester_id = $1) AS is_requested
 							 FROM __u`
 
 	updateUserRoleQuery = `UPDATE users 
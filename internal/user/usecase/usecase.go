@@ -58,16 +58,7 @@ func (u *userUC) Register(ctx context.Context, user *models.User) (*models.UserW
 	}
 	createdUser.SanitizePassword()
 
-	token, err := utils.GenerateJWTToken(createdUser, u.cfg)
-	if err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "userUC.Register.GenerateJWTToken"))
-	}
-
-	return &models.UserWithToken{
-		User:  createdUser,
-		Token: token,
-	}, nil
+	return &models.UserWithToken{User: createdUser}, nil
 }
 
 // Update existing user
@@ -232,16 +223,7 @@ func (u *userUC) Login(ctx context.Context, user *models.User) (*models.UserWith
 
 	foundUser.SanitizePassword()
 
-	token, err := utils.GenerateJWTToken(foundUser, u.cfg)
-	if err != nil {
-		tracer.AddSpanError(span, err)
-		return nil, httpErrors.NewInternalServerError(errors.Wrap(err, "userUC.Login.GenerateJWTToken"))
-	}
-
-	return &models.UserWithToken{
-		User:  foundUser,
-		Token: token,
-	}, nil
+	return &models.UserWithToken{User: foundUser}, nil
 }
 
 // Update user role
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tweets_retweets CASCADE;
+DROP TABLE IF EXISTS tweets_quotes CASCADE;
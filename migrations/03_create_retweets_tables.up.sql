@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS tweets_retweets CASCADE;
+DROP TABLE IF EXISTS tweets_quotes CASCADE;
+
+CREATE TABLE tweets_retweets
+(
+    user_id      UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    tweet_id     BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(user_id, tweet_id)
+);
+
+CREATE TABLE tweets_quotes
+(
+    tweet_id     BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    quote_id     BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    PRIMARY KEY(tweet_id)
+);
+
+CREATE INDEX tweets_retweets_tweet_id_idx ON tweets_retweets (tweet_id);
+CREATE INDEX tweets_quotes_quote_id_idx ON tweets_quotes (quote_id);
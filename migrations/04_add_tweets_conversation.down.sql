@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tweets_replys_reply_id_idx;
+DROP INDEX IF EXISTS tweets_conversation_id_idx;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS conversation_id;
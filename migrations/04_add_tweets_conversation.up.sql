@@ -0,0 +1,18 @@
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS conversation_id BIGINT REFERENCES tweets (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tweets_conversation_id_idx ON tweets (conversation_id);
+CREATE INDEX IF NOT EXISTS tweets_replys_reply_id_idx ON tweets_replys (reply_id);
+
+WITH RECURSIVE __c AS
+    (SELECT r.reply_id, r.tweet_id AS root_id
+    FROM tweets_replys r
+    WHERE NOT EXISTS (SELECT 1 FROM tweets_replys p WHERE p.reply_id = r.tweet_id)
+    UNION ALL
+    SELECT r.reply_id, __c.root_id
+    FROM tweets_replys r
+    INNER JOIN __c ON r.tweet_id = __c.reply_id
+    )
+UPDATE tweets t
+SET conversation_id = __c.root_id
+FROM __c
+WHERE t.id = __c.reply_id;
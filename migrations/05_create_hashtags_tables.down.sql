@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tweet_hashtags CASCADE;
+DROP TABLE IF EXISTS hashtags CASCADE;
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS hashtags CASCADE;
+DROP TABLE IF EXISTS tweet_hashtags CASCADE;
+
+CREATE TABLE hashtags
+(
+    id           BIGSERIAL PRIMARY KEY,
+    tag          VARCHAR(100) UNIQUE         NOT NULL CHECK ( tag <> '' ),
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE tweet_hashtags
+(
+    tweet_id     BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    hashtag_id   BIGINT                      NOT NULL REFERENCES hashtags (id) ON DELETE CASCADE,
+    PRIMARY KEY(tweet_id, hashtag_id)
+);
+
+CREATE INDEX tweet_hashtags_hashtag_id_idx ON tweet_hashtags (hashtag_id, tweet_id DESC);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS tweet_mentions CASCADE;
+
+CREATE TABLE tweet_mentions
+(
+    tweet_id     BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    user_id      UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    PRIMARY KEY(tweet_id, user_id)
+);
+
+CREATE INDEX tweet_mentions_user_id_idx ON tweet_mentions (user_id, tweet_id DESC);
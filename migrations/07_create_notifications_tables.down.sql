@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_actors CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
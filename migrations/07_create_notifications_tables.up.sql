@@ -0,0 +1,25 @@
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS notification_actors CASCADE;
+
+CREATE TABLE notifications
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    type         VARCHAR(20)                 NOT NULL CHECK ( type <> '' ),
+    tweet_id     BIGINT                      REFERENCES tweets (id) ON DELETE CASCADE,
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    read_at      TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE notification_actors
+(
+    notification_id BIGINT                      NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    actor_id        UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(notification_id, actor_id)
+);
+
+-- Events of the same kind on the same tweet are grouped into one unread notification
+CREATE UNIQUE INDEX notifications_unread_group_idx ON notifications (user_id, type, (COALESCE(tweet_id, 0))) WHERE read_at IS NULL;
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, updated_at DESC);
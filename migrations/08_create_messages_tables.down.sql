@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS messages CASCADE;
+DROP TABLE IF EXISTS conversation_members CASCADE;
+DROP TABLE IF EXISTS conversations CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS allow_dms;
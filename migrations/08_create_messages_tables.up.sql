@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS conversations CASCADE;
+DROP TABLE IF EXISTS conversation_members CASCADE;
+DROP TABLE IF EXISTS messages CASCADE;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS allow_dms BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE conversations
+(
+    id           BIGSERIAL PRIMARY KEY,
+    created_by   UUID                        REFERENCES users (user_id) ON DELETE SET NULL,
+    is_group     BOOLEAN                     NOT NULL DEFAULT FALSE,
+    name         VARCHAR(64),
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE conversation_members
+(
+    conversation_id      BIGINT                      NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    user_id              UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    last_read_message_id BIGINT                      NOT NULL DEFAULT 0,
+    joined_at            TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(conversation_id, user_id)
+);
+
+CREATE TABLE messages
+(
+    id              BIGSERIAL PRIMARY KEY,
+    conversation_id BIGINT                      NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    user_id         UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    text            VARCHAR(1000),
+    image           VARCHAR(512),
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    CHECK ( text IS NOT NULL OR image IS NOT NULL )
+);
+
+CREATE INDEX conversation_members_user_id_idx ON conversation_members (user_id);
+CREATE INDEX messages_conversation_id_idx ON messages (conversation_id, id DESC);
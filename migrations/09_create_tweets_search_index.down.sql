@@ -0,0 +1 @@
+DROP INDEX IF EXISTS tweets_text_search_idx;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tweets_text_search_idx;
+
+CREATE INDEX tweets_text_search_idx ON tweets USING GIN (to_tsvector('english', text));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS follow_requests CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_private;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS follow_requests CASCADE;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE follow_requests
+(
+    requester_id UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    target_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE CHECK ( target_id <> requester_id ),
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(requester_id, target_id)
+);
+
+CREATE INDEX follow_requests_target_id_idx ON follow_requests (target_id, created_at DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mutes CASCADE;
+DROP TABLE IF EXISTS blocks CASCADE;
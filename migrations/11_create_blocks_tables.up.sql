@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS blocks CASCADE;
+DROP TABLE IF EXISTS mutes CASCADE;
+
+CREATE TABLE blocks
+(
+    blocker_id UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    blocked_id UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE CHECK ( blocked_id <> blocker_id ),
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(blocker_id, blocked_id)
+);
+
+CREATE INDEX blocks_blocked_id_idx ON blocks (blocked_id);
+
+CREATE TABLE mutes
+(
+    muter_id   UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    muted_id   UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE CHECK ( muted_id <> muter_id ),
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(muter_id, muted_id)
+);
+
+CREATE INDEX mutes_muted_id_idx ON mutes (muted_id);
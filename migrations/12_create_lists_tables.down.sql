@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS list_subscribers CASCADE;
+DROP TABLE IF EXISTS list_members CASCADE;
+DROP TABLE IF EXISTS lists CASCADE;
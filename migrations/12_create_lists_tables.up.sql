@@ -0,0 +1,36 @@
+DROP TABLE IF EXISTS lists CASCADE;
+DROP TABLE IF EXISTS list_members CASCADE;
+DROP TABLE IF EXISTS list_subscribers CASCADE;
+
+CREATE TABLE lists
+(
+    id          BIGSERIAL PRIMARY KEY,
+    owner_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    name        VARCHAR(64)                 NOT NULL CHECK ( name <> '' ),
+    description VARCHAR(256),
+    is_private  BOOLEAN                     NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX lists_owner_id_idx ON lists (owner_id);
+
+CREATE TABLE list_members
+(
+    list_id    BIGINT                      NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(list_id, user_id)
+);
+
+CREATE INDEX list_members_user_id_idx ON list_members (user_id);
+
+CREATE TABLE list_subscribers
+(
+    list_id    BIGINT                      NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(list_id, user_id)
+);
+
+CREATE INDEX list_subscribers_user_id_idx ON list_subscribers (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tweets_bookmarks CASCADE;
+DROP TABLE IF EXISTS bookmark_folders CASCADE;
@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS bookmark_folders CASCADE;
+DROP TABLE IF EXISTS tweets_bookmarks CASCADE;
+
+CREATE TABLE bookmark_folders
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    name       VARCHAR(64)                 NOT NULL CHECK ( name <> '' ),
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE tweets_bookmarks
+(
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    tweet_id   BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    folder_id  BIGINT                      REFERENCES bookmark_folders (id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(user_id, tweet_id)
+);
+
+CREATE INDEX tweets_bookmarks_user_id_created_at_idx ON tweets_bookmarks (user_id, created_at DESC);
+CREATE INDEX tweets_bookmarks_folder_id_idx ON tweets_bookmarks (folder_id);
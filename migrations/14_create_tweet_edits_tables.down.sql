@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tweet_edits CASCADE;
+
+ALTER TABLE tweets DROP COLUMN IF EXISTS edit_count;
+ALTER TABLE tweets DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS tweet_edits CASCADE;
+
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS edit_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE tweet_edits
+(
+    id         BIGSERIAL PRIMARY KEY,
+    tweet_id   BIGINT                      NOT NULL REFERENCES tweets (id) ON DELETE CASCADE,
+    text       VARCHAR(280)                NOT NULL,
+    image      VARCHAR(512),
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL
+);
+
+CREATE INDEX tweet_edits_tweet_id_idx ON tweet_edits (tweet_id, id DESC);
@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS tweet_drafts CASCADE;
+
+CREATE TABLE tweet_drafts
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    text       VARCHAR(280)                NOT NULL CHECK ( text <> '' ),
+    image      VARCHAR(512)                CHECK ( image <> '' ),
+    publish_at TIMESTAMP WITH TIME ZONE,
+    status     VARCHAR(20)                 NOT NULL DEFAULT 'pending' CHECK ( status IN ('pending', 'publishing', 'published', 'failed', 'unknown') ),
+    tweet_id   BIGINT                      REFERENCES tweets (id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+-- Drafts stuck in publishing become unknown, they may have been published and are never claimed again
+-- Drafts without publish_at are never picked up by the scheduler
+CREATE INDEX tweet_drafts_due_idx ON tweet_drafts (publish_at) WHERE status = 'pending' AND publish_at IS NOT NULL;
+CREATE INDEX tweet_drafts_user_id_idx ON tweet_drafts (user_id, updated_at DESC);
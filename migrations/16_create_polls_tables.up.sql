@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS poll_votes CASCADE;
+DROP TABLE IF EXISTS poll_options CASCADE;
+DROP TABLE IF EXISTS polls CASCADE;
+
+CREATE TABLE polls
+(
+    tweet_id   BIGINT PRIMARY KEY          REFERENCES tweets (id) ON DELETE CASCADE,
+    ends_at    TIMESTAMP WITH TIME ZONE    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE poll_options
+(
+    tweet_id   BIGINT                      NOT NULL REFERENCES polls (tweet_id) ON DELETE CASCADE,
+    position   SMALLINT                    NOT NULL CHECK ( position BETWEEN 1 AND 4 ),
+    label      VARCHAR(25)                 NOT NULL CHECK ( label <> '' ),
+    PRIMARY KEY(tweet_id, position)
+);
+
+CREATE TABLE poll_votes
+(
+    tweet_id   BIGINT                      NOT NULL,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    position   SMALLINT                    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY(tweet_id, user_id),
+    FOREIGN KEY (tweet_id, position) REFERENCES poll_options (tweet_id, position) ON DELETE CASCADE
+);
+
+CREATE INDEX poll_votes_tweet_id_position_idx ON poll_votes (tweet_id, position);
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS tweet_media CASCADE;
+
+-- Media are uploaded first and attached to a tweet on create, tweet_id stays NULL until then
+CREATE TABLE tweet_media
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    tweet_id   BIGINT                      REFERENCES tweets (id) ON DELETE CASCADE,
+    position   SMALLINT                    CHECK ( position BETWEEN 1 AND 4 ),
+    url        VARCHAR(512)                NOT NULL CHECK ( url <> '' ),
+    width      INTEGER,
+    height     INTEGER,
+    alt_text   VARCHAR(1000),
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    UNIQUE (tweet_id, position)
+);
+
+CREATE INDEX tweet_media_user_id_idx ON tweet_media (user_id) WHERE tweet_id IS NULL;
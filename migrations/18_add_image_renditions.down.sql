@@ -0,0 +1,2 @@
+ALTER TABLE tweet_media
+    DROP COLUMN IF EXISTS blurhash;
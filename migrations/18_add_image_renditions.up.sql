@@ -0,0 +1,3 @@
+-- Images are stored as renditions, url of media is the largest one
+ALTER TABLE tweet_media
+    ADD COLUMN IF NOT EXISTS blurhash VARCHAR(64);
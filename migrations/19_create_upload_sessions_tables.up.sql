@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS upload_sessions CASCADE;
+
+-- Clients upload the object to storage with a presigned url, it is validated on complete
+CREATE TABLE upload_sessions
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    purpose      VARCHAR(20)                 NOT NULL CHECK ( purpose IN ('avatar', 'header', 'media') ),
+    object_key   VARCHAR(512)                NOT NULL,
+    content_type VARCHAR(64)                 NOT NULL,
+    status       VARCHAR(20)                 NOT NULL DEFAULT 'pending' CHECK ( status IN ('pending', 'completing', 'completed', 'failed') ),
+    media_id     BIGINT                      REFERENCES tweet_media (id) ON DELETE SET NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE    NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX upload_sessions_user_id_idx ON upload_sessions (user_id, created_at DESC);
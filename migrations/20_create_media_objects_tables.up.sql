@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS media_objects CASCADE;
+
+-- Stored images accounted to the users who uploaded them, a row per upload.
+-- Uploads of identical content share the stored image, it is removed along with its last row.
+CREATE TABLE media_objects
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    url          VARCHAR(512)                NOT NULL CHECK ( url <> '' ),
+    size         BIGINT                      NOT NULL CHECK ( size >= 0 ),
+    content_type VARCHAR(100)                NOT NULL,
+    checksum     CHAR(64)                    NOT NULL,
+    width        INTEGER                     NOT NULL,
+    height       INTEGER                     NOT NULL,
+    blurhash     VARCHAR(64)                 NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX media_objects_user_id_idx ON media_objects (user_id);
+CREATE INDEX media_objects_url_idx ON media_objects (url);
+CREATE INDEX media_objects_checksum_idx ON media_objects (checksum);
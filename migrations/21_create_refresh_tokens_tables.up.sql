@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS refresh_tokens CASCADE;
+
+-- Refresh tokens are rotated on every use, tokens rotated from the same login share a family.
+-- Rotated tokens are kept, so that their reuse is detected and revokes the family.
+CREATE TABLE refresh_tokens
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID                        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    family_id  UUID                        NOT NULL,
+    token_hash CHAR(64)                    NOT NULL UNIQUE,
+    status     VARCHAR(20)                 NOT NULL DEFAULT 'active' CHECK ( status IN ('active', 'rotated', 'revoked') ),
+    expires_at TIMESTAMP WITH TIME ZONE    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
@@ -11,14 +11,15 @@ import (
 )
 
 const (
-	ErrBadRequest         = "Bad request"
-	ErrEmailAlreadyExists = "User with given email already exists"
-	ErrNoSuchUser         = "User not found"
-	ErrWrongCredentials   = "Wrong Credentials"
-	ErrNotFound           = "Not Found"
-	ErrUnauthorized       = "Unauthorized"
-	ErrForbidden          = "Forbidden"
-	ErrBadQueryParams     = "Invalid query params"
+	ErrBadRequest           = "Bad request"
+	ErrEmailAlreadyExists   = "User with given email already exists"
+	ErrNoSuchUser           = "User not found"
+	ErrWrongCredentials     = "Wrong Credentials"
+	ErrNotFound             = "Not Found"
+	ErrUnauthorized         = "Unauthorized"
+	ErrForbidden            = "Forbidden"
+	ErrBadQueryParams       = "Invalid query params"
+	ErrStorageQuotaExceeded = "Storage quota exceeded"
 )
 
 var (
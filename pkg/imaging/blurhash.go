@@ -0,0 +1,100 @@
+package imaging
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+const (
+	blurHashComponentsX = 4
+	blurHashComponentsY = 3
+	// Blurhash is computed on a small copy, it only keeps the lowest frequencies
+	blurHashSampleSize = 32
+
+	base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+)
+
+// Encode blurhash placeholder of image, see https://blurha.sh
+func BlurHash(img *image.RGBA) string {
+	img = Fit(img, blurHashSampleSize)
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	factors := make([][3]float64, 0, blurHashComponentsX*blurHashComponentsY)
+	for j := 0; j < blurHashComponentsY; j++ {
+		for i := 0; i < blurHashComponentsX; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var r, g, b float64
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := normalisation * math.Cos(math.Pi*float64(i*x)/float64(w)) * math.Cos(math.Pi*float64(j*y)/float64(h))
+					p := img.PixOffset(x, y)
+					r += basis * sRGBToLinear(img.Pix[p])
+					g += basis * sRGBToLinear(img.Pix[p+1])
+					b += basis * sRGBToLinear(img.Pix[p+2])
+				}
+			}
+
+			scale := 1 / float64(w*h)
+			factors = append(factors, [3]float64{r * scale, g * scale, b * scale})
+		}
+	}
+
+	var hash strings.Builder
+	encodeBase83(&hash, (blurHashComponentsX-1)+(blurHashComponentsY-1)*9, 1)
+
+	maxValue := 1.0
+	ac := factors[1:]
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maxValue = float64(quantisedMax+1) / 166
+		encodeBase83(&hash, quantisedMax, 1)
+	} else {
+		encodeBase83(&hash, 0, 1)
+	}
+
+	dc := factors[0]
+	encodeBase83(&hash, linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4)
+
+	for _, f := range ac {
+		encodeBase83(&hash, quantiseAC(f[0], maxValue)*19*19+quantiseAC(f[1], maxValue)*19+quantiseAC(f[2], maxValue), 2)
+	}
+
+	return hash.String()
+}
+
+func quantiseAC(value, maxValue float64) int {
+	v := value / maxValue
+	return int(math.Max(0, math.Min(18, math.Floor(math.Copysign(math.Sqrt(math.Abs(v)), v)*9+9.5))))
+}
+
+func encodeBase83(hash *strings.Builder, value, length int) {
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		hash.WriteByte(base83Chars[digit])
+	}
+}
+
+func sRGBToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
@@ -0,0 +1,121 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Images over this pixel count are rejected before decoding
+	MaxPixels = 40 * 1000 * 1000
+
+	jpegQuality = 85
+)
+
+var ErrTooLarge = errors.New("image is too large")
+
+// Decode jpeg or png image upright, applying its exif orientation.
+// Metadata of the source is not kept on the decoded image.
+func Decode(content []byte) (*image.RGBA, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "image.DecodeConfig")
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "image.Decode")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	if format == "jpeg" {
+		img = orient(img, readOrientation(content))
+	}
+
+	return img, format, nil
+}
+
+// Encode image as png when format is png, jpeg otherwise
+func Encode(img image.Image, format string) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	if format == "png" {
+		if err := png.Encode(buf, img); err != nil {
+			return nil, errors.Wrap(err, "png.Encode")
+		}
+		return buf.Bytes(), nil
+	}
+
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, errors.Wrap(err, "jpeg.Encode")
+	}
+	return buf.Bytes(), nil
+}
+
+// Scale image down to fit in a maxSize square keeping its aspect ratio,
+// images already fitting are returned as is
+func Fit(img *image.RGBA, maxSize int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w <= maxSize && h <= maxSize {
+		return img
+	}
+
+	if w >= h {
+		h = max(1, h*maxSize/w)
+		w = maxSize
+	} else {
+		w = max(1, w*maxSize/h)
+		h = maxSize
+	}
+	return Resize(img, w, h)
+}
+
+// Resize image down to w x h averaging the source pixels covered by each target pixel
+func Resize(img *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := img.Bounds().Dx(), img.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, max((y+1)*sh/h, y*sh/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, max((x+1)*sw/w, x*sw/w+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := img.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(img.Pix[i])
+					g += int(img.Pix[i+1])
+					b += int(img.Pix[i+2])
+					a += int(img.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,122 @@
+package metric
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Orphaned file collector metrics interface
+type OrphanMetrics interface {
+	IncRuns(dryRun bool)
+	IncRunErrors()
+	AddScanned(count int)
+	AddOrphans(count int, bytes int64)
+	AddRemoved(count int, bytes int64)
+	AddRemoveErrors(count int)
+	// Push counters to a Prometheus Pushgateway, one-shot runs end before they are scraped
+	Push(url string, job string) error
+}
+
+// Prometheus orphaned file collector metrics struct
+type PrometheusOrphanMetrics struct {
+	Runs         *prometheus.CounterVec
+	RunErrors    prometheus.Counter
+	Scanned      prometheus.Counter
+	Orphans      prometheus.Counter
+	OrphanBytes  prometheus.Counter
+	Removed      prometheus.Counter
+	RemovedBytes prometheus.Counter
+	RemoveErrors prometheus.Counter
+}
+
+// Create orphaned file collector metrics with name, they are served by the metrics server of CreateMetrics
+// or pushed by Push
+func CreateOrphanMetrics(name string) (OrphanMetrics, error) {
+	var metr PrometheusOrphanMetrics
+	metr.Runs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_orphan_gc_runs_total",
+		},
+		[]string{"dry_run"},
+	)
+
+	counters := []struct {
+		counter *prometheus.Counter
+		name    string
+	}{
+		{&metr.RunErrors, "_orphan_gc_run_errors_total"},
+		{&metr.Scanned, "_orphan_gc_objects_scanned_total"},
+		{&metr.Orphans, "_orphan_gc_orphans_found_total"},
+		{&metr.OrphanBytes, "_orphan_gc_orphan_bytes_found_total"},
+		{&metr.Removed, "_orphan_gc_orphans_removed_total"},
+		{&metr.RemovedBytes, "_orphan_gc_orphan_bytes_removed_total"},
+		{&metr.RemoveErrors, "_orphan_gc_remove_errors_total"},
+	}
+
+	if err := prometheus.Register(metr.Runs); err != nil {
+		return nil, err
+	}
+	for _, c := range counters {
+		*c.counter = prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + c.name,
+		})
+		if err := prometheus.Register(*c.counter); err != nil {
+			return nil, err
+		}
+	}
+
+	return &metr, nil
+}
+
+// IncRuns
+func (metr *PrometheusOrphanMetrics) IncRuns(dryRun bool) {
+	label := "false"
+	if dryRun {
+		label = "true"
+	}
+	metr.Runs.WithLabelValues(label).Inc()
+}
+
+// IncRunErrors
+func (metr *PrometheusOrphanMetrics) IncRunErrors() {
+	metr.RunErrors.Inc()
+}
+
+// AddScanned
+func (metr *PrometheusOrphanMetrics) AddScanned(count int) {
+	metr.Scanned.Add(float64(count))
+}
+
+// AddOrphans
+func (metr *PrometheusOrphanMetrics) AddOrphans(count int, bytes int64) {
+	metr.Orphans.Add(float64(count))
+	metr.OrphanBytes.Add(float64(bytes))
+}
+
+// AddRemoved
+func (metr *PrometheusOrphanMetrics) AddRemoved(count int, bytes int64) {
+	metr.Removed.Add(float64(count))
+	metr.RemovedBytes.Add(float64(bytes))
+}
+
+// AddRemoveErrors
+func (metr *PrometheusOrphanMetrics) AddRemoveErrors(count int) {
+	metr.RemoveErrors.Add(float64(count))
+}
+
+// Push
+func (metr *PrometheusOrphanMetrics) Push(url string, job string) error {
+	pusher := push.New(url, job).Collector(metr.Runs)
+	for _, c := range []prometheus.Counter{
+		metr.RunErrors,
+		metr.Scanned,
+		metr.Orphans,
+		metr.OrphanBytes,
+		metr.Removed,
+		metr.RemovedBytes,
+		metr.RemoveErrors,
+	} {
+		pusher = pusher.Collector(c)
+	}
+	return pusher.Push()
+}
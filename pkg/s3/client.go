@@ -0,0 +1,350 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	algorithm     = "AWS4-HMAC-SHA256"
+	service       = "s3"
+	amzDateFormat = "20060102T150405Z"
+	dateFormat    = "20060102"
+	// Body of presigned requests is not part of their signature
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Client of S3-compatible object storage signing requests with AWS signature version 4,
+// objects are addressed path-style so that MinIO works without DNS setup
+type Client struct {
+	endpoint *url.URL
+	// Endpoint reachable by clients of presigned urls
+	presignEndpoint *url.URL
+	region          string
+	bucket          string
+	accessKey       string
+	secretKey       string
+	httpClient      *http.Client
+}
+
+// Return new s3 client
+func NewClient(cfg *config.Config) (*Client, error) {
+	endpoint, err := url.Parse(cfg.File.S3.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "url.Parse")
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, errors.Errorf("invalid s3 endpoint %q", cfg.File.S3.Endpoint)
+	}
+
+	presignEndpoint := endpoint
+	if cfg.File.S3.PresignEndpoint != "" {
+		if presignEndpoint, err = url.Parse(cfg.File.S3.PresignEndpoint); err != nil {
+			return nil, errors.Wrap(err, "url.Parse")
+		}
+	}
+
+	region := cfg.File.S3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &Client{
+		endpoint:        endpoint,
+		presignEndpoint: presignEndpoint,
+		region:          region,
+		bucket:          cfg.File.S3.Bucket,
+		accessKey:       cfg.File.S3.AccessKey,
+		secretKey:       cfg.File.S3.SecretKey,
+		httpClient:      &http.Client{Timeout: time.Duration(cfg.File.S3.TimeoutSeconds) * time.Second},
+	}, nil
+}
+
+// Get url of object in bucket
+func (c *Client) ObjectURL(key string) string {
+	u := *c.endpoint
+	u.Path = c.objectPath(key)
+	u.RawPath = encodePath(u.Path)
+	return u.String()
+}
+
+// Get url uploading object with a PUT of given content type until it expires
+func (c *Client) PresignPutObject(key string, contentType string, expires time.Duration) string {
+	u := *c.presignEndpoint
+	u.Path = c.objectPath(key)
+	u.RawPath = encodePath(u.Path)
+	return c.presign(http.MethodPut, &u, map[string]string{"content-type": contentType}, expires, time.Now().UTC())
+}
+
+func (c *Client) PutObject(ctx context.Context, key string, content []byte, contentType string) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.do(req, content)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get object content, the caller closes it
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Object listed in bucket
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List every object in bucket with key starting with prefix, following continuation pages
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]Object, error) {
+	objects := make([]Object, 0)
+
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	for {
+		u := *c.endpoint
+		u.Path = strings.TrimSuffix(c.endpoint.Path, "/") + "/" + c.bucket
+		u.RawPath = encodePath(u.Path)
+		u.RawQuery = canonicalQuery(query)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "http.NewRequestWithContext")
+		}
+
+		resp, err := c.do(req, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "xml.Decode")
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, LastModified: content.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, key string, content []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.ObjectURL(key), bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "http.NewRequestWithContext")
+	}
+	req.ContentLength = int64(len(content))
+	return req, nil
+}
+
+// Sign and send request, responses other than 2xx are returned as error
+func (c *Client) do(req *http.Request, content []byte) (*http.Response, error) {
+	payloadHash := sha256.Sum256(content)
+	c.sign(req, hex.EncodeToString(payloadHash[:]), time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "httpClient.Do")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, body)
+	}
+	return resp, nil
+}
+
+func (c *Client) sign(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		headers = append(headers, strings.ToLower(name))
+	}
+	sort.Strings(headers)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headers {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	signature := c.signature(req.Method, req.URL, canonicalHeaders.String(), signedHeaders, payloadHash, now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, c.accessKey, c.scope(now), signedHeaders, signature))
+	req.Header.Del("Host")
+}
+
+// Sign request in query string, headers are signed along with host and must be sent as is
+func (c *Client) presign(method string, u *url.URL, headers map[string]string, expires time.Duration, now time.Time) string {
+	headers["host"] = u.Host
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", c.accessKey+"/"+c.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	u.RawQuery = canonicalQuery(query)
+
+	signature := c.signature(method, u, canonicalHeaders.String(), signedHeaders, unsignedPayload, now)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+
+	return u.String()
+}
+
+func (c *Client) signature(method string, u *url.URL, canonicalHeaders string, signedHeaders string, payloadHash string, now time.Time) string {
+	canonicalRequest := strings.Join([]string{
+		method,
+		encodePath(u.Path),
+		canonicalQuery(u.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		algorithm,
+		now.Format(amzDateFormat),
+		c.scope(now),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), now.Format(dateFormat))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (c *Client) scope(now time.Time) string {
+	return now.Format(dateFormat) + "/" + c.region + "/" + service + "/aws4_request"
+}
+
+func (c *Client) objectPath(key string) string {
+	return strings.TrimSuffix(c.endpoint.Path, "/") + "/" + c.bucket + "/" + strings.TrimPrefix(key, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, encode(k, true)+"="+encode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func encodePath(path string) string {
+	return encode(path, false)
+}
+
+// URI encode as AWS expects, only unreserved characters are left as is
+func encode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' || (ch == '/' && !encodeSlash) {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
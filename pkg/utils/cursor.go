@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Keyset pagination cursor, the sort key and the unique id of the last row of the previous page
+type Cursor struct {
+	Key string `json:"k"`
+	ID  string `json:"i"`
+}
+
+// Encode cursor to an opaque token
+func EncodeCursor(key string, id string) string {
+	data, _ := json.Marshal(&Cursor{Key: key, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode opaque cursor token, its values are checked before they reach the keyset queries
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "invalid cursor"))
+	}
+	cursor := &Cursor{}
+	if err = json.Unmarshal(data, cursor); err != nil || cursor.Key == "" || cursor.ID == "" {
+		return nil, httpErrors.NewBadRequestError(errors.New("invalid cursor"))
+	}
+	if err = cursor.validate(); err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "invalid cursor"))
+	}
+	return cursor, nil
+}
+
+// Cursors of tweets are an activity time and a tweet id, cursors of users are a name and a user id
+func (c *Cursor) validate() error {
+	if _, err := strconv.ParseUint(c.ID, 10, 64); err == nil {
+		_, err = time.Parse(time.RFC3339Nano, c.Key)
+		return errors.Wrap(err, "time.Parse")
+	}
+	_, err := uuid.Parse(c.ID)
+	return errors.Wrap(err, "uuid.Parse")
+}
+
+// Get tweets page in cursor mode, tweets must be fetched with GetCursorLimit
+func GetTweetsCursorList(tweets []*models.TweetWithUser, pq *PaginationQuery) *models.TweetsList {
+	tweetsList := &models.TweetsList{Size: pq.GetSize(), Tweets: tweets}
+	if len(tweets) > pq.GetSize() {
+		tweetsList.Tweets = tweets[:pq.GetSize()]
+		last := tweetsList.Tweets[len(tweetsList.Tweets)-1]
+		activityAt := last.CreatedAt
+		if last.RetweetedAt != nil {
+			activityAt = *last.RetweetedAt
+		}
+		tweetsList.HasMore = true
+		tweetsList.NextCursor = EncodeCursor(activityAt.Format(time.RFC3339Nano), strconv.FormatUint(last.ID, 10))
+	}
+	return tweetsList
+}
+
+// Get users page in cursor mode, users must be fetched with GetCursorLimit
+func GetUsersCursorList(users []*models.User, pq *PaginationQuery) *models.UsersList {
+	usersList := &models.UsersList{Size: pq.GetSize(), Users: users}
+	if len(users) > pq.GetSize() {
+		usersList.Users = users[:pq.GetSize()]
+		last := usersList.Users[len(usersList.Users)-1]
+		usersList.HasMore = true
+		usersList.NextCursor = EncodeCursor(last.Name, last.UserID.String())
+	}
+	return usersList
+}
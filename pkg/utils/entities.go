@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+)
+
+const (
+	maxHashtagLength  = 100
+	maxUserNameLength = 32
+)
+
+var (
+	hashtagRegexp = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#])#([\p{L}\p{N}_]+)`)
+	mentionRegexp = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_]+)`)
+	digitsRegexp  = regexp.MustCompile(`^[0-9_]+$`)
+)
+
+// Extract unique lower case hashtags from text, without the leading #
+func ExtractHashtags(text string) []string {
+	matches := hashtagRegexp.FindAllStringSubmatch(text, -1)
+
+	seen := make(map[string]struct{}, len(matches))
+	tags := make([]string, 0, len(matches))
+	for _, m := range matches {
+		tag := strings.ToLower(m[1])
+		if len(tag) > maxHashtagLength || digitsRegexp.MatchString(tag) {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// Normalize hashtag from path or query
+func NormalizeHashtag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// Extract @user_name mentions from text in order of appearance, without the leading @.
+// Start and End are character offsets of the mention including the @, End exclusive.
+func ExtractMentions(text string) []*models.Mention {
+	matches := mentionRegexp.FindAllStringSubmatchIndex(text, -1)
+
+	mentions := make([]*models.Mention, 0, len(matches))
+	for _, m := range matches {
+		userName := text[m[2]:m[3]]
+		if utf8.RuneCountInString(userName) > maxUserNameLength {
+			continue
+		}
+		start := utf8.RuneCountInString(text[:m[2]-1])
+		mentions = append(mentions, &models.Mention{
+			UserName: userName,
+			Start:    start,
+			End:      start + 1 + utf8.RuneCountInString(userName),
+		})
+	}
+	return mentions
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
@@ -146,6 +148,42 @@ func ReadImage(ctx echo.Context, field string) (*multipart.FileHeader, error) {
 	return image, nil
 }
 
+// Read every image of a multipart form field, requests without multipart form have none
+func ReadImages(ctx echo.Context, field string) ([]*multipart.FileHeader, error) {
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			return nil, nil
+		}
+		return nil, errors.WithMessage(err, "ctx.MultipartForm")
+	}
+
+	images := form.File[field]
+	for _, image := range images {
+		if err = CheckImageContentType(image); err != nil {
+			return nil, err
+		}
+	}
+
+	return images, nil
+}
+
+// Read content of uploaded file
+func ReadFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, errors.WithMessage(err, "fileHeader.Open")
+	}
+	defer file.Close()
+
+	content := bytes.NewBuffer(nil)
+	if _, err = io.Copy(content, file); err != nil {
+		return nil, errors.WithMessage(err, "io.Copy")
+	}
+
+	return content.Bytes(), nil
+}
+
 // Read sanitize and validate request
 func SanitizeRequest(ctx echo.Context, request interface{}) error {
 	body, err := ioutil.ReadAll(ctx.Request().Body)
@@ -5,6 +5,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"path"
+	"strings"
 
 	"github.com/JamesHsu333/go-twitter/pkg/httpErrors"
 	"github.com/google/uuid"
@@ -85,3 +87,36 @@ func GetUniqFileName(userID string, fileExtension string) string {
 	randString := uuid.New().String()
 	return "userid_" + userID + "_" + randString + "." + fileExtension
 }
+
+// Image renditions stored for every uploaded image, largest first
+var ImageRenditions = []struct {
+	Name    string
+	MaxSize int
+}{
+	{Name: "large", MaxSize: 2048},
+	{Name: "medium", MaxSize: 1200},
+	{Name: "small", MaxSize: 680},
+	{Name: "thumb", MaxSize: 150},
+}
+
+// Get object key of image rendition
+func GetImageRenditionKey(prefix string, rendition string, extension string) string {
+	return prefix + "_" + rendition + "." + extension
+}
+
+// Get urls of all renditions from the url of the largest one,
+// nil for images stored before renditions were introduced
+func GetImageRenditions(url string) map[string]string {
+	extension := path.Ext(url)
+	prefix := strings.TrimSuffix(url, extension)
+	if !strings.HasSuffix(prefix, "_"+ImageRenditions[0].Name) {
+		return nil
+	}
+	prefix = strings.TrimSuffix(prefix, "_"+ImageRenditions[0].Name)
+
+	renditions := make(map[string]string, len(ImageRenditions))
+	for _, r := range ImageRenditions {
+		renditions[r.Name] = GetImageRenditionKey(prefix, r.Name, strings.TrimPrefix(extension, "."))
+	}
+	return renditions
+}
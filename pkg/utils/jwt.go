@@ -2,7 +2,7 @@ package utils
 
 import (
 	"errors"
-	"html"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -10,31 +10,43 @@ import (
 	"github.com/JamesHsu333/go-twitter/config"
 	"github.com/JamesHsu333/go-twitter/internal/models"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
 )
 
 // JWT Claims struct
 type Claims struct {
 	Email string `json:"email"`
 	ID    string `json:"id"`
+	// Refresh token family the access token was issued with, revoking the family revokes it
+	FamilyID string `json:"fid"`
 	jwt.StandardClaims
 }
 
-// Generate new JWT Token
-func GenerateJWTToken(user *models.User, config *config.Config) (string, error) {
-	// Register the JWT claims, which includes the username and expiry time
+// Generate access token signed with the signing key of the key set, its kid header names the key
+func GenerateAccessToken(user *models.User, familyID uuid.UUID, config *config.Config) (string, error) {
+	kid := strings.ToLower(config.Auth.SigningKeyID)
+	key, ok := config.Auth.Keys[kid]
+	if !ok || key == "" {
+		return "", fmt.Errorf("signing key %q is not in the key set", kid)
+	}
+
+	now := time.Now()
 	claims := &Claims{
-		Email: user.Email,
-		ID:    user.UserID.String(),
+		Email:    user.Email,
+		ID:       user.UserID.String(),
+		FamilyID: familyID.String(),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Minute * 60).Unix(),
+			Subject:   user.UserID.String(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Duration(config.Auth.AccessTokenTTLSeconds) * time.Second).Unix(),
 		},
 	}
 
 	// Declare the token with the algorithm used for signing, and the claims
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = kid
 
-	// Register the JWT string
-	tokenString, err := token.SignedString([]byte(config.Server.JwtSecretKey))
+	tokenString, err := token.SignedString([]byte(key))
 	if err != nil {
 		return "", err
 	}
@@ -42,39 +54,36 @@ func GenerateJWTToken(user *models.User, config *config.Config) (string, error)
 	return tokenString, nil
 }
 
-// Extract JWT From Request
-func ExtractJWTFromRequest(r *http.Request) (map[string]interface{}, error) {
-	// Get the JWT string
-	tokenString := ExtractBearerToken(r)
-
-	// Initialize a new instance of `Claims` (here using Claims map)
-	claims := jwt.MapClaims{}
-
-	// Parse the JWT string and repositories the result in `claims`.
-	// Note that we are passing the key in this method as well. This method will return an error
-	// if the token is invalid (if it has expired according to the expiry time we set on sign in),
-	// or if the signature does not match
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (jwtKey interface{}, err error) {
-		return jwtKey, err
+// Parse access token signed with any key of the key set, expired tokens are invalid
+func ParseAccessToken(tokenString string, config *config.Config) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, ok := config.Auth.Keys[strings.ToLower(kid)]
+		if !ok || key == "" {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return []byte(key), nil
 	})
-
 	if err != nil {
-		if errors.Is(err, jwt.ErrSignatureInvalid) {
-			return nil, errors.New("invalid token signature")
-		}
 		return nil, err
 	}
 
 	if !token.Valid {
-		return nil, errors.New("invalid token ")
+		return nil, errors.New("invalid token")
 	}
 
 	return claims, nil
 }
 
-// Extract bearer token from request Authorization header
+// Extract bearer token from request Authorization header, empty when there is none
 func ExtractBearerToken(r *http.Request) string {
-	headerAuthorization := r.Header.Get("Authorization")
-	bearerToken := strings.Split(headerAuthorization, " ")
-	return html.EscapeString(bearerToken[1])
+	headerParts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(headerParts) != 2 || !strings.EqualFold(headerParts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(headerParts[1])
 }
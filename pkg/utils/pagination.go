@@ -12,11 +12,15 @@ const (
 	defaultSize = 10
 )
 
-// Pagination query params
+// Pagination query params.
+// Only user listing orders by OrderBy, the other lists keep a fixed order that cursors can continue.
 type PaginationQuery struct {
-	Size    int    `json:"size,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	OrderBy string `json:"orderBy,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Page    int     `json:"page,omitempty"`
+	OrderBy string  `json:"orderBy,omitempty"`
+	Cursor  *Cursor `json:"cursor,omitempty"`
+	// Cursor mode skips count and offset, it is enabled by the cursor query param, empty for the first page
+	UseCursor bool `json:"-"`
 }
 
 // Set page size
@@ -54,9 +58,24 @@ func (q *PaginationQuery) SetOrderBy(orderByQuery string) {
 	q.OrderBy = orderByQuery
 }
 
-// Get offset
+// Set cursor, empty cursor starts from the first page
+func (q *PaginationQuery) SetCursor(cursorQuery string) error {
+	q.UseCursor = true
+	if cursorQuery == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(cursorQuery)
+	if err != nil {
+		return err
+	}
+	q.Cursor = cursor
+
+	return nil
+}
+
+// Get offset, cursor mode always starts after the cursor
 func (q *PaginationQuery) GetOffset() int {
-	if q.Page == 0 {
+	if q.Page == 0 || q.UseCursor {
 		return 0
 	}
 	return (q.Page - 1) * q.Size
@@ -67,6 +86,30 @@ func (q *PaginationQuery) GetLimit() int {
 	return q.Size
 }
 
+// Get limit of query, cursor mode fetches one more row to know if there is a next page
+func (q *PaginationQuery) GetCursorLimit() int {
+	if q.UseCursor {
+		return q.Size + 1
+	}
+	return q.Size
+}
+
+// Get sort key of cursor, nil without cursor
+func (q *PaginationQuery) GetCursorKey() *string {
+	if q.Cursor == nil {
+		return nil
+	}
+	return &q.Cursor.Key
+}
+
+// Get id of cursor, nil without cursor
+func (q *PaginationQuery) GetCursorID() *string {
+	if q.Cursor == nil {
+		return nil
+	}
+	return &q.Cursor.ID
+}
+
 // Get OrderBy
 func (q *PaginationQuery) GetOrderBy() string {
 	return q.OrderBy
@@ -96,6 +139,11 @@ func GetPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
 		return nil, err
 	}
 	q.SetOrderBy(c.QueryParam("orderBy"))
+	if cursor, ok := c.QueryParams()["cursor"]; ok {
+		if err := q.SetCursor(cursor[0]); err != nil {
+			return nil, err
+		}
+	}
 
 	return q, nil
 }
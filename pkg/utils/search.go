@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/JamesHsu333/go-twitter/internal/models"
+	"github.com/pkg/errors"
+)
+
+const searchDateLayout = "2006-01-02"
+
+// Parse tweet search query. Supported operators are from:user_name, has:image, -has:image,
+// since:YYYY-MM-DD, until:YYYY-MM-DD (exclusive) and min_likes:N. Everything else,
+// including "quoted phrases", OR and -excluded terms, is kept as web search text.
+func ParseTweetSearch(query string) (*models.TweetSearch, error) {
+	search := &models.TweetSearch{}
+	terms := make([]string, 0)
+
+	for _, token := range splitSearchQuery(query) {
+		i := strings.Index(token, ":")
+		if i <= 0 || i == len(token)-1 || strings.HasPrefix(token, `"`) {
+			terms = append(terms, token)
+			continue
+		}
+		operator, value := token[:i], token[i+1:]
+
+		switch strings.ToLower(operator) {
+		case "from":
+			userName := strings.TrimPrefix(value, "@")
+			search.FromUserName = &userName
+		case "has", "-has":
+			if !strings.EqualFold(value, "image") {
+				return nil, errors.Errorf("unsupported search operator %s", token)
+			}
+			hasImage := strings.EqualFold(operator, "has")
+			search.HasImage = &hasImage
+		case "since":
+			since, err := time.Parse(searchDateLayout, value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid since date %s", value)
+			}
+			search.Since = &since
+		case "until":
+			until, err := time.Parse(searchDateLayout, value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid until date %s", value)
+			}
+			search.Until = &until
+		case "min_likes":
+			minLikes, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || minLikes < 0 {
+				return nil, errors.Errorf("invalid min_likes %s", value)
+			}
+			search.MinLikes = minLikes
+		default:
+			terms = append(terms, token)
+		}
+	}
+
+	search.Text = strings.Join(terms, " ")
+	return search, nil
+}
+
+// Split query on white space, keeping "quoted phrases" together
+func splitSearchQuery(query string) []string {
+	tokens := make([]string, 0)
+	var token strings.Builder
+	inQuote := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			token.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}